@@ -43,11 +43,14 @@ func main() {
 	aiService := openrouter.NewClient(log, cfg, repositoryService)
 	scraperService := scraper.New(log, cfg, repositoryService)
 	tgBot := telegramBot.New(log, cfg, repositoryService)
-	orchestratorService := orchestrator.New(log, cfg, scraperService, aiService, repositoryService, tgBot, scraperService.CompletedEventsChan)
+	orchestratorService := orchestrator.New(log, cfg, scraperService, aiService, repositoryService, tgBot, scraperService.CompletedEventsChan, scraperService.CancelledEventsChan, scraperService.HealthAlertsChan)
 
 	// HTTP Server
 	eventHandler := handlers.NewEventHandler(log, repositoryService, orchestratorService)
-	router := routers.NewRouter(eventHandler)
+	scheduleHandler := handlers.NewScheduleHandler(log, orchestratorService)
+	scrapeRunHandler := handlers.NewScrapeRunHandler(log, repositoryService)
+	tagHandler := handlers.NewTagHandler(log, repositoryService)
+	router := routers.NewRouter(eventHandler, scheduleHandler, scrapeRunHandler, tagHandler, cfg.HttpServer.Secret)
 	httpSrv := httpServer.NewHttpServer(log, router, cfg)
 
 	maxSecond := 15 * time.Second
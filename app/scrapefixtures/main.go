@@ -0,0 +1,124 @@
+// Команда scrapefixtures записывает фикстуры сайтов для офлайн-проверки скраперов
+// и сверяет результат скраперов на записанных страницах с golden-файлами.
+//
+// Запись фикстуры (сайт берётся из конфигурации или задаётся флагами):
+//
+//	go run ./app/scrapefixtures -config config.yml -record lococlub
+//	go run ./app/scrapefixtures -record lococlub -url https://lococlub.es/events/
+//
+// Проверка всех фикстур и обновление golden-файлов после намеренных изменений скрапера:
+//
+//	go run ./app/scrapefixtures
+//	go run ./app/scrapefixtures -update
+//
+// Те же фикстуры проверяет go test ./internal/scraper/replay (флаг -update перезаписывает golden-файлы).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/scraper/replay"
+)
+
+const defaultFixturesDir = "internal/scraper/replay/testdata"
+
+func main() {
+	var (
+		configPath = flag.String("config", "", "path to config file with site settings (for -record)")
+		record     = flag.String("record", "", "record fixture for the site with this name")
+		siteURL    = flag.String("url", "", "site url for -record (overrides config)")
+		siteType   = flag.String("type", "", "scraper type for -record (overrides config)")
+		dir        = flag.String("dir", defaultFixturesDir, "fixtures directory")
+		update     = flag.Bool("update", false, "rewrite golden files with current scraper output")
+		timeout    = flag.Duration("timeout", 10*time.Minute, "timeout for recording or checking")
+	)
+	flag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	var err error
+	if *record != "" {
+		err = recordSite(ctx, *configPath, *record, *siteURL, *siteType, *dir)
+	} else {
+		err = checkAll(ctx, *dir, *update)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// recordSite записывает фикстуру сайта name в каталог dir/name.
+func recordSite(ctx context.Context, configPath, name, siteURL, siteType, dir string) error {
+	site := config.SiteConfig{Name: name}
+	fetchCfg := config.FetchConfig{}
+
+	if configPath != "" {
+		cfg := config.MustLoadPath(configPath)
+		fetchCfg = cfg.ScraperConfig.Fetch
+		for _, s := range cfg.ScraperConfig.Sites {
+			if s.Name == name {
+				site = s
+				break
+			}
+		}
+	}
+	if siteURL != "" {
+		site.URL = siteURL
+	}
+	if siteType != "" {
+		site.Type = siteType
+	}
+	if site.URL == "" {
+		return fmt.Errorf("site %s: url is not set", name)
+	}
+
+	fixtureDir := filepath.Join(dir, name)
+	events, err := replay.Record(ctx, site, fetchCfg, fixtureDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("recorded %s: %d events -> %s\n", name, len(events), fixtureDir)
+	return nil
+}
+
+// checkAll воспроизводит все фикстуры из dir и сверяет их с golden-файлами.
+func checkAll(ctx context.Context, dir string, update bool) error {
+	fixtureDirs, err := replay.FixtureDirs(dir)
+	if err != nil {
+		return err
+	}
+	if len(fixtureDirs) == 0 {
+		return fmt.Errorf("no fixtures found in %s", dir)
+	}
+
+	failed := 0
+	for _, fixtureDir := range fixtureDirs {
+		diff, err := replay.Check(ctx, fixtureDir, update)
+		switch {
+		case err != nil:
+			failed++
+			fmt.Printf("FAIL %s: %v\n", fixtureDir, err)
+		case diff != "":
+			failed++
+			fmt.Printf("FAIL %s:\n%s", fixtureDir, diff)
+		case update:
+			fmt.Printf("updated %s\n", fixtureDir)
+		default:
+			fmt.Printf("ok   %s\n", fixtureDir)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d fixtures failed", failed, len(fixtureDirs))
+	}
+	return nil
+}
@@ -122,3 +122,31 @@ func (c *AIConfig) GetTimeout() time.Duration {
 func (c *AIConfig) SetTimeout(timeout time.Duration) {
 	c.Timeout = int(timeout.Seconds())
 }
+
+// builtinSiteTimezones — часовые пояса сайтов встроенных скраперов (сайты без типа),
+// если в конфигурации часовой пояс не задан.
+var builtinSiteTimezones = map[string]string{
+	"lococlub": "Europe/Madrid",
+}
+
+// TimezoneName возвращает часовой пояс дат на сайте: из конфигурации, а для встроенного
+// скрапера без заданного часового пояса — часовой пояс его сайта. Пустая строка — UTC.
+func (s SiteConfig) TimezoneName() string {
+	if s.Timezone == "" && s.Type == "" {
+		return builtinSiteTimezones[s.Name]
+	}
+	return s.Timezone
+}
+
+// Location возвращает часовой пояс дат на сайте (см. TimezoneName). Если он не задан — UTC.
+func (s SiteConfig) Location() (*time.Location, error) {
+	name := s.TimezoneName()
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone of site %s: %w", s.Name, err)
+	}
+	return loc, nil
+}
@@ -8,6 +8,7 @@ type Config struct {
 	DBConfig       DBConfig         `yaml:"db" env-required:"true"`
 	BotConfig      BotConfig        `yaml:"bot" env-required:"true"`
 	ScraperConfig  ScraperConfig    `yaml:"scraper" env-required:"true"`
+	Images         ImagesConfig     `yaml:"images"`
 	ConfigFilePath string           `yaml:"configFilePath" env:"CONFIG_FILEPATH" env-default:""`
 	ConfigFileName string           `yaml:"configFileName" env:"CONFIG_FILENAME" env-default:""`
 	configPath     string
@@ -46,19 +47,182 @@ type AIConfig struct {
 type BotConfig struct {
 	Admins        []string `yaml:"admins" env-default:"KrAssor"`
 	ChannelIDs    []int64  `yaml:"channelIDs" env-default:"-1003669376196"`
+	AdminChatIDs  []int64  `yaml:"adminChatIDs"` // Чаты для служебных уведомлений. Если пусто — используются ChannelIDs
 	TgbotApiToken string   `yaml:"tgbot_apitoken" env:"TGBOT_APITOKEN" env-required:"true"`
 	AI            AIConfig `yaml:"AI"`
+	// Часовой пояс дат в сообщениях бота в формате IANA
+	Timezone string `yaml:"timezone" env:"BOT_TIMEZONE" env-default:"Europe/Madrid"`
+	// Часовые пояса отдельных каналов и чатов (ID чата → IANA). Если для чата не задан — используется Timezone
+	ChannelTimezones map[int64]string `yaml:"channelTimezones"`
 }
 
 // SiteConfig описывает сайт для скрапинга.
 type SiteConfig struct {
-	Name string `yaml:"name"` // Имя скрапера (например, "lococlub")
-	URL  string `yaml:"url"`  // URL страницы для скрапинга
+	Name      string         `yaml:"name"`      // Имя скрапера (например, "lococlub")
+	URL       string         `yaml:"url"`       // URL страницы для скрапинга
+	Type      string         `yaml:"type"`      // Тип скрапера ("selector", "mec", "jsonld", "ics", "feed", "api"). Если пусто — скрапер ищется по имени
+	Selectors SelectorConfig `yaml:"selectors"` // Селекторы для типов "selector" и "jsonld" или переопределения для типа "mec"
+	ICS       ICSConfig      `yaml:"ics"`       // Настройки для типа "ics"
+	Feed      FeedConfig     `yaml:"feed"`      // Настройки для типа "feed"
+	API       APIConfig      `yaml:"api"`       // Настройки для типа "api"
+	OnChange  string         `yaml:"onChange"`  // Что делать с известным событием, изменившимся на сайте: "enrich" (по умолчанию), "moderate", "keep"
+	// Часовой пояс дат на сайте в формате IANA (например, "Europe/Madrid"). Используется для типов,
+	// в настройках которых часовой пояс не задан. По умолчанию UTC, у встроенных скраперов — часовой пояс их сайта
+	Timezone string `yaml:"timezone"`
+	// Число скрапингов подряд без события, после которого оно считается отменённым.
+	// 0 — значение по умолчанию (3), отрицательное — не отслеживать пропавшие события
+	CancelAfterMisses int `yaml:"cancelAfterMisses"`
+	// Расписание скрапинга: выражение cron ("0 */4 * * *"), "@daily" или интервал ("@every 2h").
+	// Если пусто — используется ScraperConfig.Schedule
+	Schedule string        `yaml:"schedule"`
+	Jitter   time.Duration `yaml:"jitter"` // Случайный разброс времени запуска. Если 0 — используется ScraperConfig.Jitter
+	// Как долго не скачивать заново страницы уже известных событий. 0 — значение по умолчанию (24h),
+	// отрицательное — скачивать всегда
+	RefreshAfter time.Duration `yaml:"refreshAfter"`
+	// Ограничение запросов к домену сайта в секунду. Если 0 — используется FetchConfig.RateLimit
+	RateLimit  float64       `yaml:"rateLimit"`
+	CrawlDelay time.Duration `yaml:"crawlDelay"` // Минимальная пауза между запросами к домену сайта (Crawl-delay из robots.txt учитывается, если он больше)
+	Health     HealthConfig  `yaml:"health"`     // Проверки работоспособности скрапера после каждого запуска
+}
+
+// HealthConfig описывает проверки результата запуска скрапера, по которым определяется,
+// что скрапер сломался (например, после смены вёрстки сайта).
+type HealthConfig struct {
+	// Минимальное ожидаемое число событий за запуск. 0 — значение по умолчанию (1), отрицательное — не проверять
+	MinEvents int `yaml:"minEvents"`
+	// Обязательные поля событий ("name", "date", "price", "photo", "description", ...).
+	// По умолчанию "name" и "date" ("name" для типа "feed", где дату определяет AI)
+	RequiredFields []string `yaml:"requiredFields"`
+	// Допустимая доля событий без обязательных полей. 0 — значение по умолчанию (0.2), отрицательное — не проверять
+	MaxIncompleteShare float64 `yaml:"maxIncompleteShare"`
+	// Допустимое падение числа событий относительно предыдущего запуска. 0 — значение по умолчанию (0.5),
+	// отрицательное — не проверять
+	MaxDropShare float64 `yaml:"maxDropShare"`
+}
+
+// SelectorConfig описывает декларативный скрапер на CSS-селекторах.
+// Страница списка содержит ссылки на события, страница события — детали.
+type SelectorConfig struct {
+	ListItem     string   `yaml:"listItem"`     // Селектор ссылок на события на странице списка
+	LinkAttr     string   `yaml:"linkAttr"`     // Атрибут со ссылкой на событие (по умолчанию "href")
+	Name         string   `yaml:"name"`         // Селектор названия
+	Description  string   `yaml:"description"`  // Селектор описания
+	Date         string   `yaml:"date"`         // Селектор даты
+	DateLayouts  []string `yaml:"dateLayouts"`  // Форматы даты в нотации Go (например, "02 Jan 2006")
+	DateLanguage string   `yaml:"dateLanguage"` // Язык названий месяцев в дате ("es", "ca", "ru", ...). По умолчанию английский
+	Timezone     string   `yaml:"timezone"`     // Часовой пояс дат на сайте в формате IANA (например, "Europe/Madrid")
+	EndDate      string   `yaml:"endDate"`      // Селектор даты окончания многодневного события (в тех же форматах, что и дата)
+	Time         string   `yaml:"time"`         // Селектор времени или интервала времени ("20:00 - 23:00")
+	TimeLayouts  []string `yaml:"timeLayouts"`  // Форматы времени в нотации Go (например, "15:04")
+	Price        string   `yaml:"price"`        // Селектор цены
+	PriceRegex   string   `yaml:"priceRegex"`   // Регулярное выражение для чисел в тексте цены
+	Currency     string   `yaml:"currency"`     // Валюта цены, если в тексте её нет (например, "EUR")
+	Photo        string   `yaml:"photo"`        // Селектор изображения
+	PhotoAttr    string   `yaml:"photoAttr"`    // Атрибут со ссылкой на изображение (по умолчанию "src")
+	Video        string   `yaml:"video"`        // Селектор iframe/video с видео
+	VideoAttr    string   `yaml:"videoAttr"`    // Атрибут со ссылкой на видео (по умолчанию "src")
+	BuyLink      string   `yaml:"buyLink"`      // Селектор ссылки на покупку билета
+	Venue        string   `yaml:"venue"`        // Селектор названия места проведения
+	Address      string   `yaml:"address"`      // Селектор адреса места проведения
+}
+
+// ICSConfig описывает источник событий в формате iCalendar (RFC 5545).
+type ICSConfig struct {
+	HorizonDays int    `yaml:"horizonDays"` // На сколько дней вперёд разворачивать повторяющиеся события (по умолчанию 90)
+	Timezone    string `yaml:"timezone"`    // Часовой пояс для времени без TZID в формате IANA (по умолчанию UTC)
+}
+
+// FeedConfig описывает источник событий в виде RSS/Atom-ленты.
+type FeedConfig struct {
+	ContentSelector string `yaml:"contentSelector"` // Селектор полного описания на странице записи. Если пусто — по ссылкам не переходим
+	MaxItems        int    `yaml:"maxItems"`        // Максимум записей из ленты за один проход (0 — без ограничения)
+}
+
+// APIConfig описывает источник событий в виде JSON REST API.
+// Пути к полям задаются в стиле JSONPath: "events", "data.items[0].title", "$.next_rest_url".
+// Несколько альтернативных путей разделяются символом "|": "image.url|image".
+type APIConfig struct {
+	URLTemplate string              `yaml:"urlTemplate"` // Шаблон URL с плейсхолдерами {page} и {cursor}. Если пусто — используется url сайта
+	Headers     map[string]string   `yaml:"headers"`     // Дополнительные HTTP-заголовки (например, ключ API)
+	ItemsPath   string              `yaml:"itemsPath"`   // Путь к массиву событий в ответе. Если пусто — ответ сам является массивом
+	Pagination  APIPaginationConfig `yaml:"pagination"`  // Стратегия пагинации
+	Fields      APIFieldsConfig     `yaml:"fields"`      // Пути к полям события внутри элемента массива
+	DateLayouts []string            `yaml:"dateLayouts"` // Форматы даты в нотации Go. По умолчанию RFC 3339 и "2006-01-02 15:04:05"
+	Timezone    string              `yaml:"timezone"`    // Часовой пояс дат без смещения в формате IANA (по умолчанию UTC)
+	Currency    string              `yaml:"currency"`    // Валюта, если в ответе её нет
+}
+
+// APIPaginationConfig описывает стратегию пагинации JSON API.
+type APIPaginationConfig struct {
+	Type       string `yaml:"type"`       // "page" (номер страницы), "cursor", "next" (ссылка на следующую страницу) или пусто
+	StartPage  int    `yaml:"startPage"`  // Номер первой страницы для типа "page" (по умолчанию 1)
+	MaxPages   int    `yaml:"maxPages"`   // Максимум страниц за один проход (по умолчанию 20)
+	CursorPath string `yaml:"cursorPath"` // Путь к курсору следующей страницы для типа "cursor"
+	NextPath   string `yaml:"nextPath"`   // Путь к ссылке на следующую страницу для типа "next"
+}
+
+// APIFieldsConfig — пути к полям domain.Event внутри элемента ответа JSON API.
+type APIFieldsConfig struct {
+	ID          string `yaml:"id"`          // Идентификатор события (используется, если нет ссылки)
+	Name        string `yaml:"name"`        // Название
+	Description string `yaml:"description"` // Описание (HTML или текст)
+	Date        string `yaml:"date"`        // Дата начала (строка или unix-время)
+	EndDate     string `yaml:"endDate"`     // Дата окончания (строка или unix-время)
+	Price       string `yaml:"price"`       // Цена (число или текст)
+	PriceMax    string `yaml:"priceMax"`    // Максимальная цена, если цена задана диапазоном
+	Free        string `yaml:"free"`        // Признак бесплатного входа (true/false)
+	Currency    string `yaml:"currency"`    // Валюта
+	Photo       string `yaml:"photo"`       // Изображение (строка или объект с url)
+	Link        string `yaml:"link"`        // Ссылка на страницу события
+	Video       string `yaml:"video"`       // Ссылка на видео
+	Venue       string `yaml:"venue"`       // Название места проведения (строка или объект schema.org Place)
+	Address     string `yaml:"address"`     // Адрес места проведения
+	Latitude    string `yaml:"latitude"`    // Широта места проведения
+	Longitude   string `yaml:"longitude"`   // Долгота места проведения
 }
 
 type ScraperConfig struct {
-	JobBufferSize int          `yaml:"jobBufferSize" env:"SCRAPER_JOB_BUFFER_SIZE" env-default:"10"`
-	WorkersCount  int          `yaml:"workersCount" env:"SCRAPER_WORKERS_COUNT" env-default:"3"`
-	Timeout       int          `yaml:"timeout" env:"SCRAPER_TIMEOUT" env-default:"600"` //in seconds
-	Sites         []SiteConfig `yaml:"sites"`                                           // Список сайтов для скрапинга
+	JobBufferSize int           `yaml:"jobBufferSize" env:"SCRAPER_JOB_BUFFER_SIZE" env-default:"10"`
+	WorkersCount  int           `yaml:"workersCount" env:"SCRAPER_WORKERS_COUNT" env-default:"3"`
+	Timeout       int           `yaml:"timeout" env:"SCRAPER_TIMEOUT" env-default:"600"`          //in seconds
+	Sites         []SiteConfig  `yaml:"sites"`                                                    // Список сайтов для скрапинга
+	Schedule      string        `yaml:"schedule" env:"SCRAPER_SCHEDULE" env-default:"@every 6h"`  // Расписание скрапинга сайтов по умолчанию
+	Jitter        time.Duration `yaml:"jitter" env:"SCRAPER_JITTER" env-default:"5m"`             // Разброс времени запуска по умолчанию
+	RunOnStart    bool          `yaml:"runOnStart" env:"SCRAPER_RUN_ON_START" env-default:"true"` // Скрапить все сайты сразу после запуска
+	Fetch         FetchConfig   `yaml:"fetch"`                                                    // Настройки загрузки страниц
+	Gazetteer     string        `yaml:"gazetteer" env:"SCRAPER_GAZETTEER"`                        // Путь к YAML-справочнику мест проведения с координатами. Если пусто — координаты берутся только с сайтов
+	Dedup         DedupConfig   `yaml:"dedup"`                                                    // Поиск одного и того же мероприятия на разных сайтах
+}
+
+// DedupConfig описывает поиск дубликатов: одного и того же мероприятия, найденного на разных сайтах
+// (например, на сайте площадки и у агрегатора билетов) под разными ссылками.
+// События в разных известных местах дубликатами не считаются.
+type DedupConfig struct {
+	// Оценка сходства (0–1), начиная с которой новое событие считается дубликатом уже сохранённого.
+	// Отрицательная — не искать дубликаты
+	Threshold       float64       `yaml:"threshold" env:"SCRAPER_DEDUP_THRESHOLD" env-default:"0.75"`
+	SameVenueWindow time.Duration `yaml:"sameVenueWindow" env:"SCRAPER_DEDUP_SAME_VENUE_WINDOW" env-default:"12h"` // Наибольшая разница во времени начала событий в одном месте
+	MaxTimeDiff     time.Duration `yaml:"maxTimeDiff" env:"SCRAPER_DEDUP_MAX_TIME_DIFF" env-default:"2h"`          // Наибольшая разница во времени начала, если место одного из событий неизвестно
+}
+
+// ImagesConfig описывает скачивание и локальное хранение фото событий.
+type ImagesConfig struct {
+	Dir             string `yaml:"dir" env:"IMAGES_DIR" env-default:"images"`                            // Каталог (том) для хранения фото
+	MaxDownloadSize int64  `yaml:"maxDownloadSize" env:"IMAGES_MAX_DOWNLOAD_SIZE" env-default:"8388608"` // Максимальный размер скачиваемого фото в байтах
+	MaxFileSize     int64  `yaml:"maxFileSize" env:"IMAGES_MAX_FILE_SIZE" env-default:"5242880"`         // Размер в байтах, больше которого фото пережимается в JPEG
+	MaxDimension    int    `yaml:"maxDimension" env:"IMAGES_MAX_DIMENSION" env-default:"2048"`           // Максимальная ширина и высота фото; большие фото уменьшаются
+	MaxPixels       int    `yaml:"maxPixels" env:"IMAGES_MAX_PIXELS" env-default:"40000000"`             // Максимальное число пикселей фото; большие фото не декодируются и отклоняются
+	JPEGQuality     int    `yaml:"jpegQuality" env:"IMAGES_JPEG_QUALITY" env-default:"85"`               // Качество JPEG при пережатии (1–100)
+}
+
+// FetchConfig описывает общий слой загрузки страниц для скраперов.
+type FetchConfig struct {
+	Concurrency    int           `yaml:"concurrency" env:"SCRAPER_FETCH_CONCURRENCY" env-default:"4"`          // Максимум одновременных запросов к одному домену
+	RequestTimeout time.Duration `yaml:"requestTimeout" env:"SCRAPER_FETCH_REQUEST_TIMEOUT" env-default:"30s"` // Таймаут одного запроса
+	Retries        int           `yaml:"retries" env:"SCRAPER_FETCH_RETRIES" env-default:"3"`                  // Число повторов при сетевых ошибках, 429 и 5xx (отрицательное — без повторов)
+	Backoff        time.Duration `yaml:"backoff" env:"SCRAPER_FETCH_BACKOFF" env-default:"1s"`                 // Начальная пауза между повторами, удваивается с каждой попыткой
+	RateLimit      float64       `yaml:"rateLimit" env:"SCRAPER_FETCH_RATE_LIMIT" env-default:"2"`             // Запросов в секунду к одному домену по умолчанию
+	UserAgent      string        `yaml:"userAgent" env:"SCRAPER_FETCH_USER_AGENT" env-default:"eventsBot/1.0"` // User-Agent запросов; по нему же выбираются правила robots.txt
+	Contact        string        `yaml:"contact" env:"SCRAPER_FETCH_CONTACT"`                                  // Контакт для владельцев сайтов (e-mail или URL), отправляется в заголовке From и в User-Agent
+	RobotsTTL      time.Duration `yaml:"robotsTTL" env:"SCRAPER_FETCH_ROBOTS_TTL" env-default:"24h"`           // Время кеширования robots.txt
 }
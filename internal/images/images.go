@@ -0,0 +1,232 @@
+// Package images скачивает фото событий, проверяет их, уменьшает слишком большие
+// и хранит на локальном диске под хешем содержимого, чтобы публикация в Telegram
+// не зависела от доступности фото на сайте.
+package images
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // Декодер GIF для image.Decode
+	"image/jpeg"
+	_ "image/png" // Декодер PNG для image.Decode
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+)
+
+// Значения по умолчанию для настроек хранилища (config.ImagesConfig).
+const (
+	defaultMaxDownloadSize = 8 << 20
+	defaultMaxFileSize     = 5 << 20
+	defaultMaxDimension    = 2048
+	defaultMaxPixels       = 40_000_000
+	defaultJPEGQuality     = 85
+	// minJPEGQuality — ниже этого качества фото не пережимается: лучше отправить его по ссылке.
+	minJPEGQuality = 40
+	// maxAspectRatio — наибольшее отношение сторон фото, которое принимает Telegram.
+	maxAspectRatio = 20
+)
+
+// extensions — допустимые типы фото и расширения их файлов.
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ErrInvalidImage — скачанный файл не является допустимым фото.
+var ErrInvalidImage = errors.New("invalid image")
+
+// Fetcher скачивает файлы с сайтов не больше limit байт (см. sites.Fetcher).
+type Fetcher interface {
+	FetchLimit(ctx context.Context, rawURL string, headers map[string]string, limit int64) ([]byte, error)
+}
+
+// Store — локальное хранилище фото событий.
+type Store struct {
+	fetcher         Fetcher
+	dir             string
+	maxDownloadSize int64
+	maxFileSize     int64
+	maxDimension    int
+	maxPixels       int
+	jpegQuality     int
+}
+
+// NewStore создаёт хранилище фото в каталоге cfg.Dir, создавая каталог при необходимости.
+func NewStore(cfg config.ImagesConfig, fetcher Fetcher) (*Store, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("images dir is empty")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create images dir: %w", err)
+	}
+
+	s := &Store{
+		fetcher:         fetcher,
+		dir:             cfg.Dir,
+		maxDownloadSize: cfg.MaxDownloadSize,
+		maxFileSize:     cfg.MaxFileSize,
+		maxDimension:    cfg.MaxDimension,
+		maxPixels:       cfg.MaxPixels,
+		jpegQuality:     cfg.JPEGQuality,
+	}
+	if s.maxDownloadSize <= 0 {
+		s.maxDownloadSize = defaultMaxDownloadSize
+	}
+	if s.maxFileSize <= 0 {
+		s.maxFileSize = defaultMaxFileSize
+	}
+	if s.maxDimension <= 0 {
+		s.maxDimension = defaultMaxDimension
+	}
+	if s.maxPixels <= 0 {
+		s.maxPixels = defaultMaxPixels
+	}
+	if s.jpegQuality <= 0 || s.jpegQuality > 100 {
+		s.jpegQuality = defaultJPEGQuality
+	}
+	return s, nil
+}
+
+// Path возвращает путь к файлу фото на диске.
+func (s *Store) Path(img domain.Image) string {
+	return filepath.Join(s.dir, img.Path)
+}
+
+// Download скачивает фото по ссылке rawURL, проверяет его, при необходимости уменьшает
+// и сохраняет. referer — страница события: сайты с защитой от хотлинка отдают фото только с ней.
+func (s *Store) Download(ctx context.Context, rawURL string, referer string) (domain.Image, error) {
+	var headers map[string]string
+	if referer != "" {
+		headers = map[string]string{"Referer": referer}
+	}
+
+	data, err := s.fetcher.FetchLimit(ctx, rawURL, headers, s.maxDownloadSize)
+	if err != nil {
+		return domain.Image{}, fmt.Errorf("failed to download image: %w", err)
+	}
+
+	img, data, err := s.process(data)
+	if err != nil {
+		return domain.Image{}, fmt.Errorf("%s: %w", rawURL, err)
+	}
+	img.SourceURL = rawURL
+
+	if err := s.save(&img, data); err != nil {
+		return domain.Image{}, err
+	}
+	return img, nil
+}
+
+// process проверяет тип и размер фото по содержимому (а не по заголовкам ответа)
+// и пережимает в JPEG фото, которые больше maxDimension по одной из сторон или больше maxFileSize.
+func (s *Store) process(data []byte) (domain.Image, []byte, error) {
+	if len(data) == 0 {
+		return domain.Image{}, nil, fmt.Errorf("%w: empty file", ErrInvalidImage)
+	}
+	if int64(len(data)) > s.maxDownloadSize {
+		return domain.Image{}, nil, fmt.Errorf("%w: file is larger than %d bytes", ErrInvalidImage, s.maxDownloadSize)
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := extensions[contentType]; !ok {
+		return domain.Image{}, nil, fmt.Errorf("%w: unsupported content type %s", ErrInvalidImage, contentType)
+	}
+
+	// Декодера WebP в стандартной библиотеке нет: такие фото сохраняются как есть
+	if contentType == "image/webp" {
+		if int64(len(data)) > s.maxFileSize {
+			return domain.Image{}, nil, fmt.Errorf("%w: webp is larger than %d bytes", ErrInvalidImage, s.maxFileSize)
+		}
+		return domain.Image{ContentType: contentType}, data, nil
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return domain.Image{}, nil, fmt.Errorf("%w: %w", ErrInvalidImage, err)
+	}
+	if cfg.Width == 0 || cfg.Height == 0 || max(cfg.Width, cfg.Height) > maxAspectRatio*min(cfg.Width, cfg.Height) {
+		return domain.Image{}, nil, fmt.Errorf("%w: unsupported size %dx%d", ErrInvalidImage, cfg.Width, cfg.Height)
+	}
+	// Небольшой сжатый файл может объявить огромный размер: полное декодирование такого фото
+	// выделило бы гигабайты памяти
+	if int64(cfg.Width)*int64(cfg.Height) > int64(s.maxPixels) {
+		return domain.Image{}, nil, fmt.Errorf("%w: %dx%d is more than %d pixels", ErrInvalidImage, cfg.Width, cfg.Height, s.maxPixels)
+	}
+
+	img := domain.Image{ContentType: contentType, Width: cfg.Width, Height: cfg.Height}
+	if cfg.Width <= s.maxDimension && cfg.Height <= s.maxDimension && int64(len(data)) <= s.maxFileSize {
+		return img, data, nil
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return domain.Image{}, nil, fmt.Errorf("%w: %w", ErrInvalidImage, err)
+	}
+	resized := resize(decoded, s.maxDimension)
+
+	// Снижаем качество, пока фото не уложится в maxFileSize
+	for quality := s.jpegQuality; quality >= minJPEGQuality; quality -= 10 {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resized, &jpeg.Options{Quality: quality}); err != nil {
+			return domain.Image{}, nil, fmt.Errorf("failed to encode image: %w", err)
+		}
+		if int64(buf.Len()) <= s.maxFileSize {
+			bounds := resized.Bounds()
+			img.ContentType, img.Width, img.Height = "image/jpeg", bounds.Dx(), bounds.Dy()
+			return img, buf.Bytes(), nil
+		}
+	}
+
+	return domain.Image{}, nil, fmt.Errorf("%w: cannot compress to %d bytes", ErrInvalidImage, s.maxFileSize)
+}
+
+// save записывает фото в файл с именем по хешу содержимого: "ab/abcdef….jpg".
+// Если такой файл уже есть, он не перезаписывается.
+func (s *Store) save(img *domain.Image, data []byte) error {
+	sum := sha256.Sum256(data)
+	img.Hash = hex.EncodeToString(sum[:])
+	img.Path = filepath.Join(img.Hash[:2], img.Hash+extensions[img.ContentType])
+	img.Size = int64(len(data))
+
+	path := s.Path(*img)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create image dir: %w", err)
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы не оставить недописанный файл под хешем
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create image file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save image: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package images
+
+import (
+	"image"
+	"image/color"
+)
+
+// resize уменьшает изображение так, чтобы большая сторона не превышала maxDimension.
+// Каждый пиксель результата — среднее пикселей исходного изображения, которые он покрывает.
+// Прозрачные области накладываются на белый фон: результат сохраняется в JPEG.
+func resize(src image.Image, maxDimension int) image.Image {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	dstW, dstH := srcW, srcH
+	if srcW > maxDimension || srcH > maxDimension {
+		if srcW >= srcH {
+			dstW, dstH = maxDimension, max(1, srcH*maxDimension/srcW)
+		} else {
+			dstW, dstH = max(1, srcW*maxDimension/srcH), maxDimension
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0 := bounds.Min.Y + y*srcH/dstH
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcH/dstH)
+
+		for x := 0; x < dstW; x++ {
+			x0 := bounds.Min.X + x*srcW/dstW
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcW/dstW)
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					// Цвета premultiplied: добавляем белый фон в долю прозрачности
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr + 0xffff - ca)
+					g += uint64(cg + 0xffff - ca)
+					b += uint64(cb + 0xffff - ca)
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+
+	return dst
+}
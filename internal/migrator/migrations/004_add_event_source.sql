@@ -0,0 +1,7 @@
+-- Снимок полей источника и отпечаток для обнаружения изменений на сайте
+ALTER TABLE events ADD COLUMN IF NOT EXISTS source JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS source_fingerprint TEXT NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS changed_fields TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS source_changed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_events_event_link ON events (event_link);
@@ -0,0 +1,18 @@
+-- Отслеживание событий, пропавших с сайта
+ALTER TABLE events ADD COLUMN IF NOT EXISTS site_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS missed_scrapes INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_events_site_name ON events (site_name);
+
+-- Опубликованные в Telegram сообщения о событиях
+CREATE TABLE IF NOT EXISTS event_posts (
+    event_id UUID NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    chat_id BIGINT NOT NULL,
+    message_id INTEGER NOT NULL,
+    is_photo BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chat_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_posts_event_id ON event_posts (event_id);
@@ -0,0 +1,2 @@
+-- Время последней загрузки страницы события: известные страницы не скачиваются повторно
+ALTER TABLE events ADD COLUMN IF NOT EXISTS source_checked_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,17 @@
+-- История запусков скраперов и результаты проверок их работоспособности
+CREATE TABLE IF NOT EXISTS scrape_runs (
+    id UUID PRIMARY KEY,
+    request_id UUID NOT NULL,
+    site_name TEXT NOT NULL,
+    status TEXT NOT NULL,
+    events_count INTEGER NOT NULL DEFAULT 0,
+    incomplete_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    blocked_count INTEGER NOT NULL DEFAULT 0,
+    problems TEXT[] NOT NULL DEFAULT '{}',
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_scrape_runs_site_started ON scrape_runs (site_name, started_at DESC);
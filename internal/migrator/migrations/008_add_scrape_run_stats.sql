@@ -0,0 +1,8 @@
+-- Итоги обработки событий в запуске скрапера и поиск запуска по идентификатору задачи
+ALTER TABLE scrape_runs ADD COLUMN IF NOT EXISTS new_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE scrape_runs ADD COLUMN IF NOT EXISTS changed_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE scrape_runs ADD COLUMN IF NOT EXISTS duplicate_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE scrape_runs ADD COLUMN IF NOT EXISTS failed_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scrape_runs_request_id ON scrape_runs (request_id);
+CREATE INDEX IF NOT EXISTS idx_scrape_runs_status_started ON scrape_runs (status, started_at DESC);
@@ -0,0 +1,21 @@
+-- Серии событий: одно мероприятие (спектакль, выставка), проходящее в разные даты.
+-- Общее содержимое и результат обогащения AI хранятся в серии, даты — в событиях серии.
+CREATE TABLE IF NOT EXISTS event_series (
+    id UUID PRIMARY KEY,
+    site_name TEXT NOT NULL DEFAULT '',
+    event_link TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    photo TEXT NOT NULL DEFAULT '',
+    map_link TEXT NOT NULL DEFAULT '',
+    tag TEXT NOT NULL DEFAULT '',
+    enriched_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_series_event_link ON event_series (event_link);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS series_id UUID REFERENCES event_series (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_series_id ON events (series_id);
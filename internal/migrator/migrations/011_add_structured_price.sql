@@ -0,0 +1,6 @@
+-- Структурированная цена: колонка price хранит минимальную цену, price_max — максимальную
+-- (0, если цена одна), а также бесплатный вход, вход за пожертвование и наличие билетов
+ALTER TABLE events ADD COLUMN IF NOT EXISTS price_max DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS price_free BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS price_donation BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS ticket_availability TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,19 @@
+-- Места проведения событий. Место определяет нормализованное название (или адрес),
+-- координаты берутся из данных сайта или локального справочника мест.
+CREATE TABLE IF NOT EXISTS venues (
+    id UUID PRIMARY KEY,
+    key TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    site_name TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_venues_key ON venues (key);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS venue_id UUID REFERENCES venues (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_venue_id ON events (venue_id);
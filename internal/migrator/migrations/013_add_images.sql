@@ -0,0 +1,17 @@
+-- Фото событий, скачанные и сохранённые локально под хешем содержимого,
+-- и file_id фото, уже загруженных в Telegram, для повторной отправки без загрузки
+CREATE TABLE IF NOT EXISTS images (
+    hash TEXT PRIMARY KEY,
+    path TEXT NOT NULL,
+    source_url TEXT NOT NULL DEFAULT '',
+    content_type TEXT NOT NULL DEFAULT '',
+    size BIGINT NOT NULL DEFAULT 0,
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0,
+    telegram_file_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_images_source_url ON images (source_url);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS image_hash TEXT REFERENCES images (hash) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+-- Дубликаты событий: одно мероприятие, найденное на разных сайтах под разными ссылками.
+-- Дубликат ссылается на каноническое событие, которое публикуется со ссылками на все источники.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS canonical_id UUID REFERENCES events (id) ON DELETE SET NULL;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS duplicate_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_events_canonical_id ON events (canonical_id);
@@ -0,0 +1,18 @@
+-- Уникальность события в источнике: одна дата мероприятия по одной ссылке.
+-- Дубликаты, сохранённые параллельными воркерами скрапера до появления ограничения,
+-- объединяются с самым ранним событием: сообщения в Telegram и ссылки дубликатов переносятся на него.
+CREATE TEMPORARY TABLE event_source_duplicates ON COMMIT DROP AS
+SELECT id, keep_id
+FROM (
+    SELECT id, first_value(id) OVER (PARTITION BY event_link, date ORDER BY created_at, id) AS keep_id
+    FROM events
+    WHERE event_link IS NOT NULL AND date IS NOT NULL
+) ranked
+WHERE id <> keep_id;
+
+UPDATE event_posts p SET event_id = d.keep_id FROM event_source_duplicates d WHERE p.event_id = d.id;
+UPDATE events e SET canonical_id = d.keep_id FROM event_source_duplicates d WHERE e.canonical_id = d.id;
+
+DELETE FROM events WHERE id IN (SELECT id FROM event_source_duplicates);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_events_source_identity ON events (event_link, date);
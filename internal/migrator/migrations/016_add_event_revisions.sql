@@ -0,0 +1,14 @@
+-- История изменений событий: разница значений полей, автор и время каждого изменения.
+-- Внешнего ключа на events нет, чтобы история удалённых событий сохранялась.
+CREATE TABLE IF NOT EXISTS event_revisions (
+    id UUID PRIMARY KEY,
+    event_id UUID NOT NULL,
+    action TEXT NOT NULL,
+    actor_type TEXT NOT NULL,
+    actor_id TEXT NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '{}',
+    reverted_to UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_revisions_event_id ON event_revisions (event_id, created_at);
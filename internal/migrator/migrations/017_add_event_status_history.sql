@@ -0,0 +1,14 @@
+-- История статусов событий: каждый переход с автором и причиной.
+-- Как и event_revisions, без внешнего ключа на events: история удалённых событий сохраняется.
+CREATE TABLE IF NOT EXISTS event_status_history (
+    id UUID PRIMARY KEY,
+    event_id UUID NOT NULL,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    actor_type TEXT NOT NULL,
+    actor_id TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_status_history_event_id ON event_status_history (event_id, created_at);
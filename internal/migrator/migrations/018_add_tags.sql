@@ -0,0 +1,50 @@
+-- Нормализованные теги событий вместо строки "#a #b" в events.tag и категория из контролируемого списка.
+-- Строки тегов существующих событий и серий переносятся при запуске (Repository.migrateLegacyTags),
+-- чтобы теги нормализовались так же, как новые; после переноса events.tag и event_series.tag пусты.
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags (name);
+
+CREATE TABLE IF NOT EXISTS event_tags (
+    event_id UUID NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (event_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_tags_tag_id ON event_tags (tag_id);
+
+-- Синонимы и переводы тегов: alias при сохранении заменяется тегом tag. Справочник редактирует администратор.
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    alias TEXT PRIMARY KEY,
+    tag TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO tag_aliases (alias, tag) VALUES
+    ('концерты', 'концерт'), ('concert', 'концерт'), ('concierto', 'концерт'), ('conciertos', 'концерт'),
+    ('выставки', 'выставка'), ('exhibition', 'выставка'), ('exposición', 'выставка'), ('exposicion', 'выставка'), ('exposició', 'выставка'),
+    ('фестивали', 'фестиваль'), ('festival', 'фестиваль'), ('festivales', 'фестиваль'),
+    ('theatre', 'театр'), ('theater', 'театр'), ('teatro', 'театр'), ('teatre', 'театр'), ('спектакль', 'театр'),
+    ('cinema', 'кино'), ('cine', 'кино'), ('фильм', 'кино'),
+    ('standup', 'стендап'), ('comedy', 'стендап'), ('комедия', 'стендап'),
+    ('party', 'вечеринка'), ('fiesta', 'вечеринка'), ('festa', 'вечеринка'),
+    ('workshop', 'мастеркласс'), ('taller', 'мастеркласс'),
+    ('lecture', 'лекция'), ('conferencia', 'лекция'),
+    ('kids', 'детям'), ('infantil', 'детям'), ('детское', 'детям'),
+    ('jazz', 'джаз'), ('rock', 'рок'), ('рокмузыка', 'рок'), ('pop', 'поп'), ('flamenco', 'фламенко'),
+    ('electronic', 'электроника'), ('electrónica', 'электроника'), ('электронная', 'электроника'),
+    ('classical', 'классика'), ('clásica', 'классика'), ('классическаямузыка', 'классика')
+ON CONFLICT (alias) DO NOTHING;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_events_category ON events (category);
+
+-- Серия хранит категорию и теги, которые переносятся в её новые даты
+ALTER TABLE event_series ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT '';
+ALTER TABLE event_series ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
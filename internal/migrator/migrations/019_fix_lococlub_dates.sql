@@ -0,0 +1,26 @@
+-- Даты lococlub до разбора в часовом поясе сайта сохранялись как мадридское местное время с пометкой UTC.
+-- Переводим их в настоящий момент времени, иначе повторный скрапинг не находит события по ссылке и дате.
+-- Дата в снимке источника исправляется так же, а отпечаток снимка пересчитывается (см. SourceSnapshot.Fingerprint),
+-- чтобы исправление не выглядело изменением на сайте.
+UPDATE events
+SET date = (date AT TIME ZONE 'UTC') AT TIME ZONE 'Europe/Madrid'
+WHERE date IS NOT NULL
+  AND (site_name = 'lococlub' OR (site_name = '' AND event_link ~ '^https?://(www\.)?lococlub\.es/'));
+
+UPDATE events
+SET source = jsonb_set(source, '{date}', to_jsonb(to_char(date AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')))
+WHERE source ? 'date'
+  AND date IS NOT NULL
+  AND (site_name = 'lococlub' OR (site_name = '' AND event_link ~ '^https?://(www\.)?lococlub\.es/'));
+
+UPDATE events e
+SET source_fingerprint = (
+    SELECT encode(sha256(string_agg(
+        convert_to(s.key, 'UTF8') || '\x00'::bytea || convert_to(btrim(s.value, E' \t\n\r\f' || chr(11)), 'UTF8') || '\x00'::bytea,
+        ''::bytea ORDER BY s.key COLLATE "C"
+    )), 'hex')
+    FROM jsonb_each_text(e.source) AS s
+)
+WHERE e.source ? 'date'
+  AND e.source_fingerprint <> ''
+  AND (e.site_name = 'lococlub' OR (e.site_name = '' AND e.event_link ~ '^https?://(www\.)?lococlub\.es/'));
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"net/url"
+	"time"
+)
+
+// Форматы дат событий в сообщениях.
+const (
+	dateLayout     = "02.01.2006"
+	dateTimeLayout = "02.01.2006 15:04"
+	timeLayout     = "15:04"
+)
+
+// DefaultEventDuration — длительность события с известным временем начала, но без окончания.
+// Используется для ссылок на добавление в календарь.
+const DefaultEventDuration = 2 * time.Hour
+
+// AllDayDate возвращает полночь UTC календарного дня, на который приходится t в своём часовом поясе.
+// Так хранятся даты событий на целые дни: они не должны сдвигаться при показе в другом часовом поясе.
+func AllDayDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// NormalizeDates приводит даты события к единому виду: даты событий на целые дни — к AllDayDate,
+// а окончание, не наступающее позже начала, сбрасывается.
+func (e *Event) NormalizeDates() {
+	if e.AllDay {
+		if !e.Date.IsZero() {
+			e.Date = AllDayDate(e.Date)
+		}
+		if !e.EndDate.IsZero() {
+			e.EndDate = AllDayDate(e.EndDate)
+		}
+	}
+	if !e.EndDate.IsZero() && !e.EndDate.After(e.Date) {
+		e.EndDate = time.Time{}
+	}
+}
+
+// End возвращает момент окончания события. Если окончание неизвестно, событие считается
+// длящимся DefaultEventDuration, а событие на целые дни — до конца своего последнего дня.
+func (e Event) End() time.Time {
+	if e.AllDay {
+		last := e.Date
+		if !e.EndDate.IsZero() {
+			last = e.EndDate
+		}
+		return last.AddDate(0, 0, 1)
+	}
+	if !e.EndDate.IsZero() {
+		return e.EndDate
+	}
+	return e.Date.Add(DefaultEventDuration)
+}
+
+// IsMultiDay проверяет, идёт ли событие больше одного дня в часовом поясе loc.
+func (e Event) IsMultiDay(loc *time.Location) bool {
+	if e.EndDate.IsZero() {
+		return false
+	}
+	if e.AllDay {
+		return e.EndDate.After(e.Date)
+	}
+	start, end := e.Date.In(loc), e.EndDate.In(loc)
+	return start.YearDay() != end.YearDay() || start.Year() != end.Year()
+}
+
+// FormatDates возвращает даты события в часовом поясе loc: "02.01.2006 15:04",
+// "02.01.2006 15:04–17:00", "02.01.2006 15:04 – 03.01.2006 12:00" или "02.01.2006 – 05.01.2006"
+// для событий на целые дни. Для события без даты возвращает пустую строку.
+func (e Event) FormatDates(loc *time.Location) string {
+	switch {
+	case e.Date.IsZero():
+		return ""
+	case e.AllDay && e.IsMultiDay(loc):
+		return e.Date.UTC().Format(dateLayout) + " – " + e.EndDate.UTC().Format(dateLayout)
+	case e.AllDay:
+		return e.Date.UTC().Format(dateLayout)
+	case e.EndDate.IsZero():
+		return e.Date.In(loc).Format(dateTimeLayout)
+	case e.IsMultiDay(loc):
+		return e.Date.In(loc).Format(dateTimeLayout) + " – " + e.EndDate.In(loc).Format(dateTimeLayout)
+	default:
+		return e.Date.In(loc).Format(dateTimeLayout) + "–" + e.EndDate.In(loc).Format(timeLayout)
+	}
+}
+
+// CalendarLink возвращает ссылку на добавление события в Google Календарь с его длительностью.
+// Для события без даты возвращает пустую строку.
+func (e Event) CalendarLink() string {
+	if e.Date.IsZero() {
+		return ""
+	}
+
+	var dates string
+	if e.AllDay {
+		// Для событий на целые дни Google Календарь ожидает даты без времени и не включает последнюю
+		dates = e.Date.UTC().Format("20060102") + "/" + e.End().UTC().Format("20060102")
+	} else {
+		dates = e.Date.UTC().Format("20060102T150405Z") + "/" + e.End().UTC().Format("20060102T150405Z")
+	}
+
+	query := url.Values{}
+	query.Set("action", "TEMPLATE")
+	query.Set("text", e.Name)
+	query.Set("dates", dates)
+	if e.EventLink != "" {
+		query.Set("details", e.EventLink)
+	}
+
+	return "https://calendar.google.com/calendar/render?" + query.Encode()
+}
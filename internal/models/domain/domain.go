@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,24 +21,68 @@ const (
 	EventStatusApproved EventStatus = "APPROVED"
 	// EventStatusRejected — событие отклонено
 	EventStatusRejected EventStatus = "REJECTED"
+	// EventStatusCancelled — событие отменено или пропало с сайта
+	EventStatusCancelled EventStatus = "CANCELLED"
+	// EventStatusDuplicate — событие найдено на другом сайте и объединено с каноническим (см. Event.CanonicalID)
+	EventStatusDuplicate EventStatus = "DUPLICATE"
 )
 
+// ErrEventNotFound — событие не найдено.
+var ErrEventNotFound = errors.New("event not found")
+
 // Event - доменная модель мероприятия
 type Event struct {
 	ID                  uuid.UUID
 	Name                string
 	Photo               string
+	ImageHash           string // Хеш локальной копии фото (см. Image); пусто, если фото не скачано
 	Description         string
-	Date                time.Time
-	Price               float64
-	Currency            string
+	Date                time.Time // Начало события
+	EndDate             time.Time // Окончание события; нулевое, если неизвестно
+	AllDay              bool      // Событие на целые дни: Date и EndDate — полночь UTC первого и последнего дня
+	Price               Price
 	EventLink           string
 	MapLink             string
 	VideoURL            string
 	CalendarLinkIOS     string
 	CalendarLinkAndroid string
-	Tag                 string
+	Category            Category // Категория из контролируемого списка; пустая, пока событие не обогащено
+	Tags                []string // Нормализованные теги (см. NormalizeTag)
 	Status              EventStatus
+	SiteName            string         // Имя сайта из конфигурации, с которого получено событие
+	SeriesID            uuid.UUID      // Серия, к которой относится событие; uuid.Nil, если серии нет
+	VenueID             uuid.UUID      // Место проведения; uuid.Nil, если неизвестно
+	Venue               Venue          // Место проведения из источника; заполняется при скрапинге, из БД не загружается
+	CanonicalID         uuid.UUID      // Каноническое событие, дубликатом которого является это; uuid.Nil, если событие не дубликат
+	DuplicateScore      float64        // Оценка сходства с каноническим событием (0–1)
+	Source              SourceSnapshot // Поля события в том виде, в каком их отдал источник
+	ChangedFields       []string       // Поля источника, изменившиеся при последнем скрапинге
+}
+
+// UpsertResult — итог сохранения события, найденного скрапером (см. Repository.CreateEvent).
+type UpsertResult string
+
+const (
+	// UpsertCreated — событие сохранено впервые
+	UpsertCreated UpsertResult = "created"
+	// UpsertUpdated — событие уже было сохранено, изменившиеся в источнике поля обновлены
+	UpsertUpdated UpsertResult = "updated"
+	// UpsertUnchanged — событие уже было сохранено и в источнике не изменилось
+	UpsertUnchanged UpsertResult = "unchanged"
+)
+
+// EventPost — сообщение в Telegram, в котором опубликовано событие.
+type EventPost struct {
+	EventID   uuid.UUID
+	ChatID    int64
+	MessageID int
+	IsPhoto   bool // Сообщение с фото: текст хранится в подписи
+}
+
+// EventCancellation — событие, признанное отменённым, и причина этого.
+type EventCancellation struct {
+	Event  Event
+	Reason string
 }
 
 type EventURL string
@@ -0,0 +1,13 @@
+package domain
+
+// Image — фото события, скачанное с сайта и сохранённое локально под хешем содержимого.
+type Image struct {
+	Hash           string // SHA-256 содержимого файла в hex
+	Path           string // Путь к файлу относительно каталога изображений
+	SourceURL      string // Ссылка, по которой фото скачано впервые
+	ContentType    string
+	Size           int64
+	Width          int // 0, если размеры неизвестны (например, для WebP)
+	Height         int
+	TelegramFileID string // file_id фото, уже загруженного в Telegram; пусто, если ещё не загружалось
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TicketAvailability — наличие билетов на событие.
+type TicketAvailability string
+
+const (
+	// TicketAvailabilityUnknown — источник не сообщает о наличии билетов
+	TicketAvailabilityUnknown TicketAvailability = ""
+	// TicketAvailabilityAvailable — билеты в продаже
+	TicketAvailabilityAvailable TicketAvailability = "AVAILABLE"
+	// TicketAvailabilitySoldOut — билеты распроданы
+	TicketAvailabilitySoldOut TicketAvailability = "SOLD_OUT"
+)
+
+// Price — цена события в том виде, в каком её удалось разобрать из источника.
+type Price struct {
+	Min          float64            // Минимальная цена; 0, если неизвестна
+	Max          float64            // Максимальная цена; 0, если цена одна
+	Currency     string             // Код валюты (например, "EUR")
+	Free         bool               // Вход свободный
+	Donation     bool               // Вход за пожертвование («taquilla inversa», «donativo»)
+	Availability TicketAvailability // Наличие билетов
+}
+
+// IsZero проверяет, что о цене ничего не известно.
+func (p Price) IsZero() bool {
+	return p == Price{}
+}
+
+// HasRange проверяет, что у цены есть диапазон «от — до».
+func (p Price) HasRange() bool {
+	return p.Max > p.Min
+}
+
+// Normalize приводит цену к единому виду: бесплатное событие без чисел получает нулевую цену,
+// максимум, не превышающий минимум, сбрасывается, а код валюты переводится в верхний регистр.
+func (p *Price) Normalize() {
+	p.Currency = strings.ToUpper(strings.TrimSpace(p.Currency))
+	if p.Min > p.Max && p.Max > 0 {
+		p.Min, p.Max = p.Max, p.Min
+	}
+	if !p.HasRange() {
+		p.Max = 0
+	}
+	if p.Free && p.Min > 0 {
+		// Есть платные билеты — «бесплатно» относится лишь к части мест
+		p.Free = false
+	}
+}
+
+// Format возвращает цену для показа пользователю: "Бесплатно", "10 EUR", "10–25 EUR",
+// "Пожертвование от 5 EUR" с отметкой о распроданных билетах. Если о цене ничего не известно,
+// возвращает пустую строку.
+func (p Price) Format() string {
+	var text string
+	switch {
+	case p.Free:
+		text = "Бесплатно"
+	case p.Donation && p.Min > 0:
+		text = "Пожертвование от " + formatAmount(p.Min) + currencySuffix(p.Currency)
+	case p.Donation:
+		text = "По пожертвованию"
+	case p.HasRange():
+		text = formatAmount(p.Min) + "–" + formatAmount(p.Max) + currencySuffix(p.Currency)
+	case p.Min > 0:
+		text = formatAmount(p.Min) + currencySuffix(p.Currency)
+	}
+
+	if p.Availability == TicketAvailabilitySoldOut {
+		if text == "" {
+			return "Билеты распроданы"
+		}
+		text += ", билеты распроданы"
+	}
+	return text
+}
+
+// formatAmount форматирует сумму без лишних нулей после запятой.
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
+
+func currencySuffix(currency string) string {
+	if currency == "" {
+		return ""
+	}
+	return " " + currency
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActorType — кто изменил событие.
+type ActorType string
+
+const (
+	// ActorSystem — изменение без известного автора (например, при публикации в Telegram)
+	ActorSystem ActorType = "system"
+	// ActorScraper — скрапер; ID — имя сайта
+	ActorScraper ActorType = "scraper"
+	// ActorAI — обогащение AI; ID — модель
+	ActorAI ActorType = "ai"
+	// ActorAPI — пользователь HTTP API; ID — пользователь или адрес клиента
+	ActorAPI ActorType = "api"
+	// ActorTelegram — пользователь Telegram; ID — username или ID пользователя
+	ActorTelegram ActorType = "telegram"
+)
+
+// Actor — автор изменения события.
+type Actor struct {
+	Type ActorType
+	ID   string
+}
+
+// String возвращает автора в виде "type:id".
+func (a Actor) String() string {
+	if a.ID == "" {
+		return string(a.Type)
+	}
+	return string(a.Type) + ":" + a.ID
+}
+
+type actorContextKey struct{}
+
+// WithActor возвращает контекст, изменения событий в котором записываются в историю от имени actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext возвращает автора изменений из контекста. Если автор не задан — ActorSystem.
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorContextKey{}).(Actor); ok && actor.Type != "" {
+		return actor
+	}
+	return Actor{Type: ActorSystem}
+}
+
+// RevisionAction — вид изменения события в истории.
+type RevisionAction string
+
+const (
+	// RevisionCreated — событие сохранено впервые
+	RevisionCreated RevisionAction = "created"
+	// RevisionUpdated — поля события изменены
+	RevisionUpdated RevisionAction = "updated"
+	// RevisionReverted — поля события возвращены к состоянию после более ранней ревизии
+	RevisionReverted RevisionAction = "reverted"
+	// RevisionDeleted — событие удалено
+	RevisionDeleted RevisionAction = "deleted"
+)
+
+// FieldChange — значения поля события до и после изменения в текстовом виде БД; nil — NULL.
+type FieldChange struct {
+	Old *string
+	New *string
+}
+
+var (
+	// ErrRevisionNotFound — ревизия не найдена.
+	ErrRevisionNotFound = errors.New("revision not found")
+	// ErrRevisionOfOtherEvent — ревизия относится к другому событию.
+	ErrRevisionOfOtherEvent = errors.New("revision belongs to another event")
+	// ErrRevisionConflict — после отката событие совпало бы с другим событием (та же ссылка и дата).
+	ErrRevisionConflict = errors.New("reverted event conflicts with another event")
+)
+
+// EventRevision — запись истории изменений события.
+type EventRevision struct {
+	ID         uuid.UUID
+	EventID    uuid.UUID
+	Action     RevisionAction
+	Actor      Actor
+	Changes    map[string]FieldChange // Изменившиеся поля по именам колонок
+	RevertedTo uuid.UUID              // Ревизия, к которой возвращено событие (для RevisionReverted)
+	CreatedAt  time.Time
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScrapeRunStatus — итог проверки работоспособности запуска скрапера.
+type ScrapeRunStatus string
+
+const (
+	// ScrapeRunStatusOK — скрапер отработал нормально
+	ScrapeRunStatusOK ScrapeRunStatus = "OK"
+	// ScrapeRunStatusDegraded — скрапер отработал, но результат подозрительный (вероятно, сменилась вёрстка)
+	ScrapeRunStatusDegraded ScrapeRunStatus = "DEGRADED"
+	// ScrapeRunStatusFailed — скрапер завершился с ошибкой
+	ScrapeRunStatusFailed ScrapeRunStatus = "FAILED"
+)
+
+// ErrScrapeRunNotFound — запуск скрапера не найден.
+var ErrScrapeRunNotFound = errors.New("scrape run not found")
+
+// ScrapeRun — запуск скрапера сайта и результаты проверки его работоспособности.
+type ScrapeRun struct {
+	ID              uuid.UUID
+	RequestID       uuid.UUID // Идентификатор задачи скрапера
+	SiteName        string
+	Status          ScrapeRunStatus
+	EventsCount     int      // Число найденных событий, включая пропущенные и запрещённые robots.txt страницы
+	IncompleteCount int      // Число событий без обязательных полей
+	SkippedCount    int      // Число известных событий, страницы которых не скачивались
+	BlockedCount    int      // Число страниц, запрещённых robots.txt
+	NewCount        int      // Число новых событий
+	ChangedCount    int      // Число известных событий, изменившихся на сайте
+	DuplicateCount  int      // Число известных событий без изменений
+	FailedCount     int      // Число событий, которые не удалось сохранить
+	Problems        []string // Описание нарушенных проверок
+	Error           string   // Ошибка скрапера
+	StartedAt       time.Time
+	FinishedAt      time.Time
+}
+
+// Duration возвращает длительность запуска.
+func (r ScrapeRun) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// ScrapeRunFilter — условия выборки истории запусков скраперов. Пустые поля не ограничивают выборку.
+type ScrapeRunFilter struct {
+	SiteName string
+	Status   ScrapeRunStatus
+	Limit    int
+	Offset   int
+}
+
+// ScrapeRunAlert — изменение работоспособности скрапера сайта, о котором нужно сообщить админам.
+type ScrapeRunAlert struct {
+	Run            ScrapeRun
+	PreviousStatus ScrapeRunStatus // Статус предыдущего запуска; пустой, если запуск первый
+}
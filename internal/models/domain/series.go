@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventSeries — мероприятие, проходящее в разные даты (например, спектакль с несколькими показами).
+// События серии — её отдельные даты; описание, фото, теги и результат обогащения AI у них общие.
+// Серию определяет ссылка на страницу мероприятия.
+type EventSeries struct {
+	ID          uuid.UUID
+	SiteName    string
+	EventLink   string
+	Name        string
+	Description string
+	Photo       string
+	MapLink     string
+	Category    Category
+	Tags        []string
+	EnrichedAt  time.Time // Когда серия обогащена AI; нулевое — ещё не обогащалась
+}
+
+// NewEventSeries создаёт серию для события, у которого её ещё нет.
+func NewEventSeries(e Event) EventSeries {
+	return EventSeries{
+		ID:        uuid.New(),
+		SiteName:  e.SiteName,
+		EventLink: e.EventLink,
+		Name:      e.Name,
+		Photo:     e.Photo,
+	}
+}
+
+// IsEnriched сообщает, что серия уже обогащена AI и её содержимое можно переносить в новые даты.
+func (s EventSeries) IsEnriched() bool {
+	return !s.EnrichedAt.IsZero()
+}
+
+// SetContent запоминает в серии содержимое события, обогащённого AI.
+func (s *EventSeries) SetContent(e Event) {
+	s.Name = e.Name
+	s.Description = e.Description
+	s.MapLink = e.MapLink
+	s.Category = e.Category
+	s.Tags = e.Tags
+	if e.Photo != "" {
+		s.Photo = e.Photo
+	}
+}
+
+// ApplyTo переносит общее содержимое серии в событие. Фото и ссылка на карту серии используются,
+// только если у события своих нет: ссылку на карту событию строит его место проведения.
+func (s EventSeries) ApplyTo(e *Event) {
+	if strings.TrimSpace(s.Name) != "" {
+		e.Name = s.Name
+	}
+	if strings.TrimSpace(s.Description) != "" {
+		e.Description = s.Description
+	}
+	if e.MapLink == "" {
+		e.MapLink = s.MapLink
+	}
+	if s.Category != "" {
+		e.Category = s.Category
+	}
+	if len(s.Tags) > 0 {
+		e.Tags = s.Tags
+	}
+	if e.Photo == "" {
+		e.Photo = s.Photo
+	}
+	e.SeriesID = s.ID
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Поля события, которые приходят из источника и сравниваются при повторном скрапинге.
+const (
+	SourceFieldName         = "name"
+	SourceFieldPhoto        = "photo"
+	SourceFieldDescription  = "description"
+	SourceFieldDate         = "date"
+	SourceFieldEndDate      = "end_date"
+	SourceFieldAllDay       = "all_day"
+	SourceFieldPrice        = "price"
+	SourceFieldPriceMax     = "price_max"
+	SourceFieldPriceFree    = "price_free"
+	SourceFieldDonation     = "donation"
+	SourceFieldAvailability = "availability"
+	SourceFieldCurrency     = "currency"
+	SourceFieldMapLink      = "map_link"
+	SourceFieldVideoURL     = "video_url"
+)
+
+// SourceSnapshot — значения полей события в том виде, в каком их отдал источник,
+// до обогащения AI и правок модераторов. Ключи — константы SourceField*.
+type SourceSnapshot map[string]string
+
+// NewSourceSnapshot снимает значения полей источника с только что спарсенного события.
+func NewSourceSnapshot(e Event) SourceSnapshot {
+	snapshot := SourceSnapshot{
+		SourceFieldName:        e.Name,
+		SourceFieldPhoto:       e.Photo,
+		SourceFieldDescription: e.Description,
+		SourceFieldCurrency:    e.Price.Currency,
+		SourceFieldMapLink:     e.MapLink,
+		SourceFieldVideoURL:    e.VideoURL,
+	}
+	if !e.Date.IsZero() {
+		snapshot[SourceFieldDate] = e.Date.UTC().Format(time.RFC3339)
+	}
+	if !e.EndDate.IsZero() {
+		snapshot[SourceFieldEndDate] = e.EndDate.UTC().Format(time.RFC3339)
+	}
+	if e.AllDay {
+		snapshot[SourceFieldAllDay] = strconv.FormatBool(e.AllDay)
+	}
+	// Поля структурированной цены добавляются, только если заданы: иначе у событий,
+	// сохранённых до их появления, изменился бы отпечаток
+	if !e.Price.IsZero() {
+		snapshot[SourceFieldPrice] = strconv.FormatFloat(e.Price.Min, 'f', -1, 64)
+	}
+	if e.Price.Max != 0 {
+		snapshot[SourceFieldPriceMax] = strconv.FormatFloat(e.Price.Max, 'f', -1, 64)
+	}
+	if e.Price.Free {
+		snapshot[SourceFieldPriceFree] = strconv.FormatBool(e.Price.Free)
+	}
+	if e.Price.Donation {
+		snapshot[SourceFieldDonation] = strconv.FormatBool(e.Price.Donation)
+	}
+	if e.Price.Availability != TicketAvailabilityUnknown {
+		snapshot[SourceFieldAvailability] = string(e.Price.Availability)
+	}
+	return snapshot
+}
+
+// Fingerprint возвращает хеш содержимого снимка. Пустой снимок (событие, сохранённое
+// до появления отпечатков) даёт пустую строку.
+func (s SourceSnapshot) Fingerprint() string {
+	if len(s) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write([]byte(strings.TrimSpace(s[key])))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Diff возвращает отсортированный список полей, значения которых отличаются от prev.
+func (s SourceSnapshot) Diff(prev SourceSnapshot) []string {
+	var changed []string
+	for key, value := range s {
+		if strings.TrimSpace(prev[key]) != strings.TrimSpace(value) {
+			changed = append(changed, key)
+		}
+	}
+	for key, value := range prev {
+		if _, ok := s[key]; !ok && strings.TrimSpace(value) != "" {
+			changed = append(changed, key)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// ApplySourceFields переносит в dst значения перечисленных полей источника из src.
+func ApplySourceFields(dst *Event, src Event, fields []string) {
+	for _, field := range fields {
+		switch field {
+		case SourceFieldName:
+			dst.Name = src.Name
+		case SourceFieldPhoto:
+			dst.Photo = src.Photo
+		case SourceFieldDescription:
+			dst.Description = src.Description
+		case SourceFieldDate:
+			dst.Date = src.Date
+		case SourceFieldEndDate:
+			dst.EndDate = src.EndDate
+		case SourceFieldAllDay:
+			dst.AllDay = src.AllDay
+		case SourceFieldPrice:
+			dst.Price.Min = src.Price.Min
+		case SourceFieldPriceMax:
+			dst.Price.Max = src.Price.Max
+		case SourceFieldPriceFree:
+			dst.Price.Free = src.Price.Free
+		case SourceFieldDonation:
+			dst.Price.Donation = src.Price.Donation
+		case SourceFieldAvailability:
+			dst.Price.Availability = src.Price.Availability
+		case SourceFieldCurrency:
+			dst.Price.Currency = src.Price.Currency
+		case SourceFieldMapLink:
+			dst.MapLink = src.MapLink
+		case SourceFieldVideoURL:
+			dst.VideoURL = src.VideoURL
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// statusTransitions — допустимые переходы статусов события и авторы, которым они разрешены.
+// Переходов из REJECTED нет: это окончательное решение модератора. Из CANCELLED скрапер
+// возвращает событие, которое снова появилось на сайте: отмена по пропускам в выдаче могла
+// быть ложной. В DUPLICATE событие попадает только при создании (см. scraper/dedup).
+var statusTransitions = map[EventStatus]map[EventStatus][]ActorType{
+	EventStatusNew: {
+		EventStatusAIEnriched:     {ActorAI},
+		EventStatusReadyToApprove: {ActorAPI},
+		EventStatusRejected:       {ActorAPI},
+		EventStatusCancelled:      {ActorScraper, ActorAPI},
+	},
+	EventStatusAIEnriched: {
+		EventStatusNew:            {ActorScraper}, // Источник изменился — AI обогащает заново
+		EventStatusReadyToApprove: {ActorScraper, ActorAPI},
+		EventStatusApproved:       {ActorAPI, ActorTelegram}, // Telegram — вместе с другой датой серии
+		EventStatusRejected:       {ActorAPI, ActorTelegram},
+		EventStatusCancelled:      {ActorScraper, ActorAPI},
+	},
+	EventStatusReadyToApprove: {
+		EventStatusNew:        {ActorScraper, ActorAPI},
+		EventStatusAIEnriched: {ActorAPI}, // Возврат, если публикация на модерацию не удалась
+		EventStatusApproved:   {ActorAPI, ActorTelegram, ActorSystem},
+		EventStatusRejected:   {ActorAPI, ActorTelegram},
+		EventStatusCancelled:  {ActorScraper, ActorAPI},
+	},
+	EventStatusApproved: {
+		EventStatusNew:            {ActorScraper},
+		EventStatusReadyToApprove: {ActorScraper},
+		EventStatusCancelled:      {ActorScraper, ActorAPI},
+	},
+	EventStatusCancelled: {
+		EventStatusNew:            {ActorScraper}, // Отменено до обработки AI
+		EventStatusReadyToApprove: {ActorScraper},
+	},
+	EventStatusDuplicate: {
+		EventStatusNew: {ActorAPI}, // Модератор отделил ошибочно объединённый дубликат
+	},
+}
+
+// ErrInvalidTransition — переход статуса события недопустим.
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// TransitionError — недопустимый переход статуса события; errors.Is(err, ErrInvalidTransition) == true.
+type TransitionError struct {
+	From  EventStatus
+	To    EventStatus
+	Actor Actor
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s is not allowed for %s", ErrInvalidTransition, e.From, e.To, e.Actor.Type)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// CheckTransition проверяет, может ли actor перевести событие из статуса from в статус to.
+// Сохранение текущего статуса допустимо всегда. Возвращает *TransitionError, если переход недопустим.
+func CheckTransition(from, to EventStatus, actor Actor) error {
+	if from == to || slices.Contains(statusTransitions[from][to], actor.Type) {
+		return nil
+	}
+	return &TransitionError{From: from, To: to, Actor: actor}
+}
+
+// StatusChange — запись истории статусов события.
+type StatusChange struct {
+	ID        uuid.UUID
+	EventID   uuid.UUID
+	From      EventStatus // Пустой при создании события
+	To        EventStatus
+	Actor     Actor
+	Reason    string
+	CreatedAt time.Time
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckTransition(t *testing.T) {
+	var (
+		scraper  = Actor{Type: ActorScraper, ID: "lococlub"}
+		ai       = Actor{Type: ActorAI, ID: "model"}
+		api      = Actor{Type: ActorAPI, ID: "admin@example.com"}
+		telegram = Actor{Type: ActorTelegram, ID: "moderator"}
+		system   = Actor{Type: ActorSystem}
+	)
+
+	tests := []struct {
+		name    string
+		from    EventStatus
+		to      EventStatus
+		actor   Actor
+		allowed bool
+	}{
+		{"same status", EventStatusRejected, EventStatusRejected, telegram, true},
+		{"ai enriches new event", EventStatusNew, EventStatusAIEnriched, ai, true},
+		{"scraper cannot enrich", EventStatusNew, EventStatusAIEnriched, scraper, false},
+		{"api sends new event to moderation", EventStatusNew, EventStatusReadyToApprove, api, true},
+		{"ai cannot send to moderation", EventStatusNew, EventStatusReadyToApprove, ai, false},
+		{"scraper cancels new event", EventStatusNew, EventStatusCancelled, scraper, true},
+		{"telegram cannot cancel", EventStatusNew, EventStatusCancelled, telegram, false},
+		{"enriched event changed in source", EventStatusAIEnriched, EventStatusNew, scraper, true},
+		{"api cannot reset enrichment", EventStatusAIEnriched, EventStatusNew, api, false},
+		{"telegram approves series date", EventStatusAIEnriched, EventStatusApproved, telegram, true},
+		{"telegram approves", EventStatusReadyToApprove, EventStatusApproved, telegram, true},
+		{"system approves", EventStatusReadyToApprove, EventStatusApproved, system, true},
+		{"scraper cannot approve", EventStatusReadyToApprove, EventStatusApproved, scraper, false},
+		{"telegram rejects", EventStatusReadyToApprove, EventStatusRejected, telegram, true},
+		{"api returns failed publication", EventStatusReadyToApprove, EventStatusAIEnriched, api, true},
+		{"approved event changed in source", EventStatusApproved, EventStatusReadyToApprove, scraper, true},
+		{"approved cannot be rejected", EventStatusApproved, EventStatusRejected, api, false},
+		{"approved cannot be unapproved by telegram", EventStatusApproved, EventStatusReadyToApprove, telegram, false},
+		{"rejected is final", EventStatusRejected, EventStatusNew, scraper, false},
+		{"rejected is final for api", EventStatusRejected, EventStatusReadyToApprove, api, false},
+		{"scraper restores cancelled event", EventStatusCancelled, EventStatusNew, scraper, true},
+		{"scraper restores cancelled event to moderation", EventStatusCancelled, EventStatusReadyToApprove, scraper, true},
+		{"api cannot restore cancelled event", EventStatusCancelled, EventStatusNew, api, false},
+		{"cancelled cannot be approved", EventStatusCancelled, EventStatusApproved, scraper, false},
+		{"api splits duplicate", EventStatusDuplicate, EventStatusNew, api, true},
+		{"scraper cannot split duplicate", EventStatusDuplicate, EventStatusNew, scraper, false},
+		{"only dedup marks duplicates", EventStatusNew, EventStatusDuplicate, api, false},
+		{"unknown status", EventStatus("ARCHIVED"), EventStatusNew, api, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTransition(tt.from, tt.to, tt.actor)
+			if tt.allowed {
+				if err != nil {
+					t.Errorf("CheckTransition(%s, %s, %s) = %v, want nil", tt.from, tt.to, tt.actor, err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidTransition) {
+				t.Fatalf("CheckTransition(%s, %s, %s) = %v, want ErrInvalidTransition", tt.from, tt.to, tt.actor, err)
+			}
+			var transitionErr *TransitionError
+			if !errors.As(err, &transitionErr) || transitionErr.From != tt.from || transitionErr.To != tt.to || transitionErr.Actor != tt.actor {
+				t.Errorf("error = %#v, want TransitionError %s -> %s by %s", err, tt.from, tt.to, tt.actor)
+			}
+		})
+	}
+}
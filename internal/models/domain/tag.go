@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Category — категория события из контролируемого списка: AI выбирает категорию только из него.
+type Category string
+
+const (
+	CategoryConcert    Category = "concert"
+	CategoryExhibition Category = "exhibition"
+	CategoryFestival   Category = "festival"
+	CategoryTheatre    Category = "theatre"
+	CategoryCinema     Category = "cinema"
+	CategoryComedy     Category = "comedy"
+	CategoryParty      Category = "party"
+	CategorySport      Category = "sport"
+	CategoryLecture    Category = "lecture"
+	CategoryWorkshop   Category = "workshop"
+	CategoryTour       Category = "tour"
+	CategoryKids       Category = "kids"
+	CategoryMarket     Category = "market"
+	CategoryOther      Category = "other"
+)
+
+// categories — список категорий и их названия для хэштегов в Telegram.
+var categories = []struct {
+	category Category
+	label    string
+}{
+	{CategoryConcert, "концерт"},
+	{CategoryExhibition, "выставка"},
+	{CategoryFestival, "фестиваль"},
+	{CategoryTheatre, "театр"},
+	{CategoryCinema, "кино"},
+	{CategoryComedy, "стендап"},
+	{CategoryParty, "вечеринка"},
+	{CategorySport, "спорт"},
+	{CategoryLecture, "лекция"},
+	{CategoryWorkshop, "мастеркласс"},
+	{CategoryTour, "экскурсия"},
+	{CategoryKids, "детям"},
+	{CategoryMarket, "ярмарка"},
+	{CategoryOther, "другое"},
+}
+
+// Categories возвращает все категории в порядке показа.
+func Categories() []Category {
+	result := make([]Category, len(categories))
+	for i, c := range categories {
+		result[i] = c.category
+	}
+	return result
+}
+
+// Label возвращает название категории для хэштега; для неизвестной категории — пустую строку.
+func (c Category) Label() string {
+	for _, known := range categories {
+		if known.category == c {
+			return known.label
+		}
+	}
+	return ""
+}
+
+// ParseCategory возвращает категорию по её коду ("concert") или названию ("#Концерт").
+// Второе значение false, если такой категории нет в списке.
+func ParseCategory(value string) (Category, bool) {
+	normalized := NormalizeTag(value)
+	for _, known := range categories {
+		if normalized == string(known.category) || normalized == known.label {
+			return known.category, true
+		}
+	}
+	return "", false
+}
+
+// CategoryFromTags возвращает категорию первого тега, совпадающего с кодом или названием категории.
+func CategoryFromTags(tags []string) (Category, bool) {
+	for _, tag := range tags {
+		if category, ok := ParseCategory(tag); ok {
+			return category, true
+		}
+	}
+	return "", false
+}
+
+// NormalizeTag приводит тег к виду, в котором он хранится: нижний регистр, без "#",
+// пробелов и знаков препинания («#Живая музыка» → «живаямузыка»), чтобы тег оставался хэштегом.
+// Синонимы и переводы тегов разрешает справочник синонимов (TagAlias) при сохранении.
+func NormalizeTag(tag string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(tag) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// NormalizeTags нормализует теги, убирая пустые и повторяющиеся; порядок тегов сохраняется.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	var result []string
+	for _, tag := range tags {
+		normalized := NormalizeTag(tag)
+		if normalized == "" || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		result = append(result, normalized)
+	}
+	return result
+}
+
+// ParseHashtags разбирает строку тегов в прежнем формате "#концерт #джаз ".
+func ParseHashtags(value string) []string {
+	return NormalizeTags(strings.FieldsFunc(value, func(r rune) bool {
+		return r == '#' || unicode.IsSpace(r) || r == ','
+	}))
+}
+
+// Hashtags возвращает категорию и теги события хэштегами для сообщения: "#концерт #джаз".
+func (e Event) Hashtags() string {
+	var parts []string
+	if label := e.Category.Label(); label != "" {
+		parts = append(parts, "#"+label)
+	}
+	for _, tag := range e.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Tag — нормализованный тег и число событий с ним.
+type Tag struct {
+	ID          uuid.UUID
+	Name        string
+	EventsCount int
+}
+
+// TagAlias — синоним или перевод тега, который при сохранении заменяется тегом Tag
+// (например, «concert» и «концерты» → «концерт»). Справочник редактирует администратор.
+type TagAlias struct {
+	Alias string
+	Tag   string
+}
+
+// ErrTagAliasNotFound — синоним тега не найден.
+var ErrTagAliasNotFound = errors.New("tag alias not found")
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"джаз", "джаз"},
+		{"#Концерт", "концерт"},
+		{"#Живая музыка", "живаямузыка"},
+		{"  Rock'n'Roll!  ", "rocknroll"},
+		{"Música_en_vivo", "música_en_vivo"},
+		{"80s", "80s"},
+		{"stand-up", "standup"},
+		{"ÉLECTRONIQUE", "électronique"},
+		{"#", ""},
+		{"🎷", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeTag(tt.tag); got != tt.want {
+			t.Errorf("NormalizeTag(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestParseHashtags(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"#концерт #джаз ", []string{"концерт", "джаз"}},
+		{"#Концерт#Джаз", []string{"концерт", "джаз"}},
+		{"jazz, blues,  soul", []string{"jazz", "blues", "soul"}},
+		{"#джаз #Джаз #ДЖАЗ", []string{"джаз"}},
+		{"#rock\n#pop\t#indie", []string{"rock", "pop", "indie"}},
+		{"#живая_музыка #!", []string{"живая_музыка"}},
+		{" # , ", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := ParseHashtags(tt.value); !slices.Equal(got, tt.want) {
+			t.Errorf("ParseHashtags(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseCategory(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Category
+		ok    bool
+	}{
+		{"concert", CategoryConcert, true},
+		{"#Концерт", CategoryConcert, true},
+		{"Stand-up", "", false},
+		{"стендап", CategoryComedy, true},
+		{"  #ярмарка ", CategoryMarket, true},
+		{"концерты", "", false}, // Синонимы разрешает справочник, а не список категорий
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := ParseCategory(tt.value); got != tt.want || ok != tt.ok {
+			t.Errorf("ParseCategory(%q) = %q, %v; want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCategoryFromTags(t *testing.T) {
+	if got, ok := CategoryFromTags([]string{"джаз", "концерт", "выставка"}); got != CategoryConcert || !ok {
+		t.Errorf("CategoryFromTags() = %q, %v; want %q", got, ok, CategoryConcert)
+	}
+	if got, ok := CategoryFromTags([]string{"джаз", "блюз"}); got != "" || ok {
+		t.Errorf("CategoryFromTags() without category tags = %q, %v", got, ok)
+	}
+}
+
+func TestEventHashtags(t *testing.T) {
+	tests := []struct {
+		event Event
+		want  string
+	}{
+		{Event{Category: CategoryConcert, Tags: []string{"джаз", "живаямузыка"}}, "#концерт #джаз #живаямузыка"},
+		{Event{Tags: []string{"джаз"}}, "#джаз"},
+		{Event{Category: "unknown", Tags: []string{"джаз"}}, "#джаз"},
+		{Event{Category: CategoryKids}, "#детям"},
+		{Event{}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.event.Hashtags(); got != tt.want {
+			t.Errorf("Hashtags() for %q %q = %q, want %q", tt.event.Category, tt.event.Tags, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Venue — место проведения событий (клуб, театр, зал).
+// Место определяет его нормализованное название (или адрес, если названия нет), см. Key.
+type Venue struct {
+	ID        uuid.UUID
+	Name      string
+	Address   string
+	Latitude  float64
+	Longitude float64
+	SiteName  string // Сайт, с которого место получено впервые
+}
+
+// IsZero проверяет, что о месте ничего не известно.
+func (v Venue) IsZero() bool {
+	return strings.TrimSpace(v.Name) == "" && strings.TrimSpace(v.Address) == ""
+}
+
+// HasCoordinates проверяет, известны ли координаты места.
+func (v Venue) HasCoordinates() bool {
+	return v.Latitude != 0 || v.Longitude != 0
+}
+
+// Key возвращает ключ места для поиска дубликатов: название (или адрес, если названия нет)
+// в нижнем регистре, без диакритики и знаков препинания.
+func (v Venue) Key() string {
+	if strings.TrimSpace(v.Name) != "" {
+		return NormalizeVenueName(v.Name)
+	}
+	return NormalizeVenueName(v.Address)
+}
+
+// Label возвращает название и адрес места через запятую.
+func (v Venue) Label() string {
+	var parts []string
+	for _, part := range []string{v.Name, v.Address} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// MapLink возвращает ссылку на место в Google Maps: по координатам, если они известны,
+// иначе — поиск по названию и адресу. Для неизвестного места возвращает пустую строку.
+func (v Venue) MapLink() string {
+	query := v.Label()
+	if v.HasCoordinates() {
+		query = strconv.FormatFloat(v.Latitude, 'f', -1, 64) + "," + strconv.FormatFloat(v.Longitude, 'f', -1, 64)
+	}
+	if query == "" {
+		return ""
+	}
+	return "https://www.google.com/maps/search/?api=1&query=" + url.QueryEscape(query)
+}
+
+// diacriticsReplacer заменяет буквы с диакритикой, встречающиеся в названиях, на базовые.
+var diacriticsReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c", "ß", "ss", "ё", "е",
+)
+
+// NormalizeVenueName приводит название или адрес места к виду для сравнения (см. NormalizeText).
+func NormalizeVenueName(name string) string {
+	return NormalizeText(name)
+}
+
+// NormalizeText приводит текст к виду для сравнения: нижний регистр, без диакритики,
+// знаки препинания заменены пробелами, пробелы схлопнуты.
+func NormalizeText(text string) string {
+	text = diacriticsReplacer.Replace(strings.ToLower(text))
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, text)
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -4,14 +4,29 @@ import (
 	"encoding/json"
 	"eventsBot/internal/models/domain"
 	"fmt"
-
-	//"strconv"
+	"strconv"
 	"strings"
-	//"time"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// aiDateLayouts — форматы даты, которые AI может вернуть в поле date.
+var aiDateLayouts = []string{
+	"02.01.2006 15:04",
+	"2006-01-02 15:04",
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"02.01.2006",
+	"2006-01-02",
+}
+
+// aiDateOnlyLayouts — форматы из aiDateLayouts без времени: такие даты означают событие на целые дни.
+var aiDateOnlyLayouts = map[string]bool{
+	"02.01.2006": true,
+	"2006-01-02": true,
+}
+
 // FlexibleStringSlice — тип, который при десериализации принимает как строку, так и массив строк.
 type FlexibleStringSlice []string
 
@@ -42,89 +57,146 @@ type EventStructuredResponseSchema struct {
 	Name string `json:"name" description:"Название мероприятия"`
 	//Photo               string              `json:"photo" description:"Ссылка на фото мероприятия"`
 	Description string `json:"description" description:"Описание мероприятия"`
-	//Date                string              `json:"date" description:"Дата и время мероприятия"`
-	//Price               string              `json:"price" description:"Цена билета на мероприятие"`
-	//Currency            string              `json:"currency" description:"Валюта цены (например: EUR, USD, RUB)"`
+	Date        string `json:"date" description:"Дата и время мероприятия в формате ДД.ММ.ГГГГ ЧЧ:ММ, если их нет в исходных данных, иначе пустая строка"`
+	EndDate     string `json:"end_date" description:"Дата и время окончания мероприятия в формате ДД.ММ.ГГГГ ЧЧ:ММ, если даты нет в исходных данных, а мероприятие длится несколько дней или известно время окончания, иначе пустая строка"`
+	Price       string `json:"price" description:"Минимальная цена билета числом, если её нет в исходных данных, иначе пустая строка"`
+	PriceMax    string `json:"price_max" description:"Максимальная цена билета числом, если цены нет в исходных данных, а билеты стоят по-разному, иначе пустая строка"`
+	Currency    string `json:"currency" description:"Валюта цены (например: EUR, USD, RUB), если цена определена, иначе пустая строка"`
+	Free        bool   `json:"free" description:"true, если цены нет в исходных данных, а вход на мероприятие бесплатный, иначе false"`
 	//EventLink           string              `json:"event_link" description:"Ссылка на страницу мероприятия"`
-	MapLink      string              `json:"map_link" description:"Ссылка на местоположение на карте"`
 	CalendarLink string              `json:"calendar_link" description:"Ссылка для добавления в календарь"`
-	Tag          FlexibleStringSlice `json:"tag" description:"Теги мероприятия (например: концерт, выставка, фестиваль)"`
+	Category     string              `json:"category" description:"Категория мероприятия — одно значение из списка допустимых"`
+	Tag          FlexibleStringSlice `json:"tag" description:"Теги мероприятия: жанры и темы (например: джаз, рок, современное искусство)"`
 }
 
 func (e EventStructuredResponseSchema) ToDomain() domain.Event {
-	// Парсинг цены: убираем лишние пробелы и возможные символы валюты, если они попали в строку
-	// priceStr := strings.TrimSpace(e.Price)
-	// priceStr = strings.ReplaceAll(priceStr, ",", ".")
-	// price, _ := strconv.ParseFloat(priceStr, 64)
-
-	// Парсинг даты: пробуем несколько форматов
-	// layouts := []string{
-	// 	"02.01.2006 15:04",
-	// 	"2006-01-02 15:04",
-	// 	time.RFC3339,
-	// }
-
-	// var eventDate time.Time
-	// for _, layout := range layouts {
-	// 	if t, err := time.Parse(layout, e.Date); err == nil {
-	// 		eventDate = t
-	// 		break
-	// 	}
-	// }
-
-	var tags strings.Builder
-	for _, tag := range e.Tag {
-		tag = strings.ReplaceAll(tag, " ", "")
-		fmt.Fprintf(&tags, "#%s ", tag)
-	}
-
-	return domain.Event{
+	price, _ := e.parsePrice()
+	eventDate, allDay, _ := parseAIDate(e.Date, time.UTC)
+	endDate, _, _ := parseAIDate(e.EndDate, time.UTC)
+
+	event := domain.Event{
 		ID:   uuid.New(),
 		Name: e.Name,
 		//Photo:               e.Photo,
 		Description: e.Description,
-		//Date:                eventDate,
-		//Price:               price,
-		//Currency:            e.Currency,
+		Date:        eventDate,
+		EndDate:     endDate,
+		AllDay:      allDay,
+		Price:       price,
 		//EventLink:           e.EventLink,
-		MapLink:             e.MapLink,
 		CalendarLinkAndroid: e.CalendarLink,
-		Tag:                 tags.String(),
 	}
+	e.applyTags(&event)
+	event.NormalizeDates()
+	return event
 }
 
 // ApplyToEvent применяет данные из AI-ответа к существующему событию.
-// Обновляет только те поля, которые AI вернул непустыми.
-func (e EventStructuredResponseSchema) ApplyToEvent(event domain.Event) domain.Event {
+// Обновляет только те поля, которые AI вернул непустыми. Дата без смещения считается
+// местным временем loc — часового пояса сайта события.
+func (e EventStructuredResponseSchema) ApplyToEvent(event domain.Event, loc *time.Location) domain.Event {
 	// Обновляем описание, если AI вернул непустое
 	if strings.TrimSpace(e.Description) != "" {
 		event.Description = e.Description
 	}
 
-	// Обновляем тег
-	if len(e.Tag) > 0 {
-		var tags strings.Builder
-		for _, tag := range e.Tag {
-			tag = strings.ReplaceAll(tag, " ", "")
-			fmt.Fprintf(&tags, "#%s ", tag)
-		}
-		event.Tag = tags.String()
+	// Обновляем категорию и теги
+	e.applyTags(&event)
+
+	// Если AI обновил название, применяем
+	if strings.TrimSpace(e.Name) != "" {
+		event.Name = e.Name
 	}
 
-	// Обновляем ссылку на карту
-	if strings.TrimSpace(e.MapLink) != "" {
-		event.MapLink = e.MapLink
+	// Дату и цену AI заполняет только для источников, где их нет (например, RSS)
+	if event.Date.IsZero() {
+		if date, allDay, ok := parseAIDate(e.Date, loc); ok {
+			event.Date = date
+			event.AllDay = allDay
+			event.EndDate, _, _ = parseAIDate(e.EndDate, loc)
+			event.NormalizeDates()
+		}
+	}
+	if event.Price.IsZero() {
+		if price, ok := e.parsePrice(); ok {
+			event.Price = price
+		}
 	}
 
-	// Обновляем ссылки на календари
-	if strings.TrimSpace(e.CalendarLink) != "" {
+	// Ссылку на календарь строим по датам события: AI не знает его длительности
+	if link := event.CalendarLink(); link != "" {
+		event.CalendarLinkAndroid = link
+	} else if strings.TrimSpace(e.CalendarLink) != "" {
 		event.CalendarLinkAndroid = e.CalendarLink
 	}
 
-	// Если AI обновил название, применяем
-	if strings.TrimSpace(e.Name) != "" {
-		event.Name = e.Name
+	return event
+}
+
+// applyTags заполняет категорию и теги события из AI-ответа. Если модель вернула категорию не из списка,
+// категория определяется по тегам, а если и это не удалось — ставится CategoryOther.
+// Ответ без категории и тегов событие не меняет.
+func (e EventStructuredResponseSchema) applyTags(event *domain.Event) {
+	tags := domain.NormalizeTags(e.Tag)
+	if strings.TrimSpace(e.Category) == "" && len(tags) == 0 {
+		return
 	}
 
-	return event
+	category, ok := domain.ParseCategory(e.Category)
+	if !ok {
+		category, ok = domain.CategoryFromTags(tags)
+	}
+	if !ok {
+		category = domain.CategoryOther
+	}
+	event.Category = category
+	if len(tags) > 0 {
+		event.Tags = tags
+	}
+}
+
+// parseAIDate разбирает дату из AI-ответа, пробуя несколько форматов.
+// Дата без смещения считается местным временем loc. allDay сообщает, что в дате нет времени.
+func parseAIDate(value string, loc *time.Location) (date time.Time, allDay bool, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false, false
+	}
+	for _, layout := range aiDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, aiDateOnlyLayouts[layout], true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// parsePrice разбирает цену из AI-ответа: минимальную и максимальную цену, валюту и бесплатный вход.
+// Второе значение false, если AI не определил ни цену, ни бесплатный вход.
+func (e EventStructuredResponseSchema) parsePrice() (domain.Price, bool) {
+	price := domain.Price{Currency: strings.TrimSpace(e.Currency), Free: e.Free}
+	price.Min, _ = parseAIAmount(e.Price)
+	price.Max, _ = parseAIAmount(e.PriceMax)
+	if price.Min == 0 {
+		price.Min, price.Max = price.Max, 0
+	}
+	price.Normalize()
+
+	if price.Min == 0 && !price.Free {
+		return domain.Price{}, false
+	}
+	return price, true
+}
+
+// parseAIAmount разбирает сумму из AI-ответа: убирает пробелы и возможные символы валюты.
+func parseAIAmount(value string) (float64, bool) {
+	amount := strings.TrimSpace(value)
+	amount = strings.ReplaceAll(amount, ",", ".")
+	amount = strings.TrimFunc(amount, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	parsed, err := strconv.ParseFloat(amount, 64)
+	if err != nil || parsed <= 0 {
+		return 0, false
+	}
+	return parsed, true
 }
@@ -1,9 +1,11 @@
 package repositories
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type BaseModel struct {
@@ -14,17 +16,128 @@ type BaseModel struct {
 
 type Event struct {
 	BaseModel
-	Name                string    `db:"name"`
-	Photo               string    `db:"photo"`
-	Description         string    `db:"description"`
-	Date                time.Time `db:"date"`
-	Price               float64   `db:"price"`
-	Currency            string    `db:"currency"`
-	EventLink           string    `db:"event_link"`
-	MapLink             string    `db:"map_link"`
-	VideoURL            string    `db:"video_url"`
-	CalendarLinkIOS     string    `db:"calendar_link_ios"`
-	CalendarLinkAndroid string    `db:"calendar_link_android"`
-	Tag                 string    `db:"tag"`
-	Status              string    `db:"status"`
+	Name                string         `db:"name"`
+	Photo               string         `db:"photo"`
+	ImageHash           sql.NullString `db:"image_hash"`
+	Description         string         `db:"description"`
+	Date                time.Time      `db:"date"`
+	EndDate             sql.NullTime   `db:"end_date"`
+	AllDay              bool           `db:"all_day"`
+	Price               float64        `db:"price"`
+	PriceMax            float64        `db:"price_max"`
+	PriceFree           bool           `db:"price_free"`
+	PriceDonation       bool           `db:"price_donation"`
+	TicketAvailability  string         `db:"ticket_availability"`
+	Currency            string         `db:"currency"`
+	EventLink           string         `db:"event_link"`
+	MapLink             string         `db:"map_link"`
+	VideoURL            string         `db:"video_url"`
+	CalendarLinkIOS     string         `db:"calendar_link_ios"`
+	CalendarLinkAndroid string         `db:"calendar_link_android"`
+	Category            string         `db:"category"`
+	Tags                pq.StringArray `db:"tags"`
+	Status              string         `db:"status"`
+	SiteName            string         `db:"site_name"`
+	SeriesID            uuid.NullUUID  `db:"series_id"`
+	VenueID             uuid.NullUUID  `db:"venue_id"`
+	CanonicalID         uuid.NullUUID  `db:"canonical_id"`
+	DuplicateScore      float64        `db:"duplicate_score"`
+	Source              string         `db:"source"`
+	SourceFingerprint   string         `db:"source_fingerprint"`
+	ChangedFields       pq.StringArray `db:"changed_fields"`
+}
+
+type EventPost struct {
+	EventID   uuid.UUID `db:"event_id"`
+	ChatID    int64     `db:"chat_id"`
+	MessageID int       `db:"message_id"`
+	IsPhoto   bool      `db:"is_photo"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type ScrapeRun struct {
+	ID              uuid.UUID      `db:"id"`
+	RequestID       uuid.UUID      `db:"request_id"`
+	SiteName        string         `db:"site_name"`
+	Status          string         `db:"status"`
+	EventsCount     int            `db:"events_count"`
+	IncompleteCount int            `db:"incomplete_count"`
+	SkippedCount    int            `db:"skipped_count"`
+	BlockedCount    int            `db:"blocked_count"`
+	NewCount        int            `db:"new_count"`
+	ChangedCount    int            `db:"changed_count"`
+	DuplicateCount  int            `db:"duplicate_count"`
+	FailedCount     int            `db:"failed_count"`
+	Problems        pq.StringArray `db:"problems"`
+	Error           string         `db:"error"`
+	StartedAt       time.Time      `db:"started_at"`
+	FinishedAt      time.Time      `db:"finished_at"`
+}
+
+type EventSeries struct {
+	BaseModel
+	SiteName    string         `db:"site_name"`
+	EventLink   string         `db:"event_link"`
+	Name        string         `db:"name"`
+	Description string         `db:"description"`
+	Photo       string         `db:"photo"`
+	MapLink     string         `db:"map_link"`
+	Category    string         `db:"category"`
+	Tags        pq.StringArray `db:"tags"`
+	EnrichedAt  sql.NullTime   `db:"enriched_at"`
+}
+
+type Venue struct {
+	BaseModel
+	Key       string          `db:"key"`
+	Name      string          `db:"name"`
+	Address   string          `db:"address"`
+	Latitude  sql.NullFloat64 `db:"latitude"`
+	Longitude sql.NullFloat64 `db:"longitude"`
+	SiteName  string          `db:"site_name"`
+}
+
+type Image struct {
+	Hash           string    `db:"hash"`
+	Path           string    `db:"path"`
+	SourceURL      string    `db:"source_url"`
+	ContentType    string    `db:"content_type"`
+	Size           int64     `db:"size"`
+	Width          int       `db:"width"`
+	Height         int       `db:"height"`
+	TelegramFileID string    `db:"telegram_file_id"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type EventRevision struct {
+	ID         uuid.UUID     `db:"id"`
+	EventID    uuid.UUID     `db:"event_id"`
+	Action     string        `db:"action"`
+	ActorType  string        `db:"actor_type"`
+	ActorID    string        `db:"actor_id"`
+	Changes    string        `db:"changes"`
+	RevertedTo uuid.NullUUID `db:"reverted_to"`
+	CreatedAt  time.Time     `db:"created_at"`
+}
+
+type StatusChange struct {
+	ID         uuid.UUID      `db:"id"`
+	EventID    uuid.UUID      `db:"event_id"`
+	FromStatus sql.NullString `db:"from_status"`
+	ToStatus   string         `db:"to_status"`
+	ActorType  string         `db:"actor_type"`
+	ActorID    string         `db:"actor_id"`
+	Reason     string         `db:"reason"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+type Tag struct {
+	ID          uuid.UUID `db:"id"`
+	Name        string    `db:"name"`
+	EventsCount int       `db:"events_count"`
+}
+
+type TagAlias struct {
+	Alias string `db:"alias"`
+	Tag   string `db:"tag"`
 }
@@ -3,6 +3,7 @@ package openrouter
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -30,6 +31,8 @@ const (
 
 type Repository interface {
 	UpdateEvent(ctx context.Context, event domain.Event) (domain.Event, error)
+	FindSeriesByID(ctx context.Context, id uuid.UUID) (domain.EventSeries, error)
+	SaveSeriesContent(ctx context.Context, series domain.EventSeries) error
 }
 
 // Job представляет задачу, передаваемую в воркер.
@@ -46,9 +49,10 @@ type Openrouter struct {
 	cfg             *config.Config     // Конфигурация приложения
 	Client          *openrouter.Client // Клиент OpenRouter API
 	repository      Repository
-	jobs            chan Job        // Канал задач
-	shutdownChannel chan struct{}   // Канал для сигнала завершения
-	wg              *sync.WaitGroup // Группа для ожидания завершения воркеров
+	locations       map[string]*time.Location // Часовые пояса сайтов по имени
+	jobs            chan Job                  // Канал задач
+	shutdownChannel chan struct{}             // Канал для сигнала завершения
+	wg              *sync.WaitGroup           // Группа для ожидания завершения воркеров
 }
 
 // NewClient создаёт новый экземпляр Openrouter.
@@ -75,11 +79,26 @@ func NewClient(
 
 	log.Info("Creating openrouter client")
 
+	// Даты событий показываются AI и разбираются из его ответа в часовом поясе сайта
+	locations := make(map[string]*time.Location, len(cfg.ScraperConfig.Sites))
+	for _, site := range cfg.ScraperConfig.Sites {
+		loc, err := site.Location()
+		if err != nil {
+			log.Error("failed to load site timezone, using UTC",
+				slog.String("name", site.Name),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		locations[site.Name] = loc
+	}
+
 	return &Openrouter{
 		logger:          logger,
 		cfg:             cfg,
 		Client:          client,
 		repository:      repository,
+		locations:       locations,
 		jobs:            make(chan Job, cfg.BotConfig.AI.JobBufferSize),
 		shutdownChannel: make(chan struct{}),
 		wg:              &sync.WaitGroup{},
@@ -152,6 +171,15 @@ func (s *Openrouter) handleJob(id int) {
 			)
 
 			ctx, cancel := context.WithTimeout(context.Background(), s.cfg.BotConfig.AI.GetTimeout())
+			// Изменения событий записываются в историю от имени модели
+			ctx = domain.WithActor(ctx, domain.Actor{Type: domain.ActorAI, ID: s.cfg.BotConfig.AI.ModelName})
+
+			// Серия уже обогащена по другой дате — переносим её содержимое без запроса к AI
+			if s.applyEnrichedSeries(ctx, joblog, job.event) {
+				cancel()
+				close(job.Done)
+				continue
+			}
 
 			// Обогащаем событие через AI
 			enrichedResponse, err := s.EnrichEventWithAI(ctx, joblog, job.requestID, job.event)
@@ -164,25 +192,78 @@ func (s *Openrouter) handleJob(id int) {
 			}
 
 			// Обновляем событие с данными от AI
-			updatedEvent := enrichedResponse.ApplyToEvent(job.event)
+			updatedEvent := enrichedResponse.ApplyToEvent(job.event, s.siteLocation(job.event.SiteName))
 			updatedEvent.Status = domain.EventStatusAIEnriched
 
 			_, err = s.repository.UpdateEvent(ctx, updatedEvent)
-			cancel() // Освобождаем контекст после всех операций
-
 			if err != nil {
-				joblog.Error("failed to update event", slog.String("error", err.Error()))
+				cancel()
+				if errors.Is(err, domain.ErrInvalidTransition) {
+					// Пока AI обогащал событие, его отклонили, отменили или отправили на модерацию
+					joblog.Warn("event status changed during enrichment, discarding result", slog.String("error", err.Error()))
+				} else {
+					joblog.Error("failed to update event", slog.String("error", err.Error()))
+				}
 				close(job.Done)
 				continue
 			}
 
+			// Запоминаем содержимое в серии, чтобы остальные даты не обогащать заново
+			if updatedEvent.SeriesID != uuid.Nil {
+				series := domain.EventSeries{ID: updatedEvent.SeriesID}
+				series.SetContent(updatedEvent)
+				if err := s.repository.SaveSeriesContent(ctx, series); err != nil {
+					joblog.Error("failed to save series content", slog.String("error", err.Error()))
+				}
+			}
+			cancel() // Освобождаем контекст после всех операций
+
 			close(job.Done)
 
-			joblog.Info("AI enrichment completed", slog.String("tag", updatedEvent.Tag))
+			joblog.Info("AI enrichment completed", slog.String("category", string(updatedEvent.Category)), slog.Any("tags", updatedEvent.Tags))
 		}
 	}
 }
 
+// applyEnrichedSeries переносит в событие содержимое его серии, если серия уже обогащена AI,
+// и сохраняет событие как обогащённое. Возвращает false, если событие нужно обогатить через AI.
+func (s *Openrouter) applyEnrichedSeries(ctx context.Context, log *slog.Logger, event domain.Event) bool {
+	if event.SeriesID == uuid.Nil {
+		return false
+	}
+
+	series, err := s.repository.FindSeriesByID(ctx, event.SeriesID)
+	if err != nil {
+		log.Error("failed to find event series", slog.String("error", err.Error()))
+		return false
+	}
+	if !series.IsEnriched() {
+		return false
+	}
+
+	series.ApplyTo(&event)
+	if link := event.CalendarLink(); link != "" {
+		event.CalendarLinkAndroid = link
+	}
+	event.Status = domain.EventStatusAIEnriched
+
+	if _, err := s.repository.UpdateEvent(ctx, event); err != nil {
+		log.Error("failed to update event", slog.String("error", err.Error()))
+		return true
+	}
+
+	log.Info("event enriched from series", slog.String("seriesID", series.ID.String()))
+	return true
+}
+
+// siteLocation возвращает часовой пояс сайта, с которого получено событие. По умолчанию UTC.
+func (s *Openrouter) siteLocation(siteName string) *time.Location {
+	if loc, ok := s.locations[siteName]; ok {
+		return loc
+	}
+	return time.UTC
+}
+
 // EnrichEventWithAI обогащает событие через AI.
 // Принимает событие и возвращает структурированный ответ с обогащёнными данными.
 func (s *Openrouter) EnrichEventWithAI(ctx context.Context, logger *slog.Logger, requestId uuid.UUID, event domain.Event) (dto.EventStructuredResponseSchema, error) {
@@ -199,26 +280,37 @@ func (s *Openrouter) EnrichEventWithAI(ctx context.Context, logger *slog.Logger,
 
 	prompt := s.cfg.BotConfig.AI.SystemRolePrompt
 
+	// Источники вроде RSS не содержат даты и цены — их AI определяет из описания
+	date := "не указана"
+	if !event.Date.IsZero() {
+		date = event.FormatDates(s.siteLocation(event.SiteName))
+	}
+	price := "не указана"
+	if formatted := event.Price.Format(); formatted != "" {
+		price = formatted
+	}
+
 	// Формируем сообщение для AI с данными события
 	eventMessage := fmt.Sprintf(`Обогати следующее событие:
 Название: %s
 Описание: %s
 Дата: %s
-Цена: %.2f %s
+Цена: %s
 Ссылка на событие: %s
 
 Задачи:
 1. Если описание меньше 50 символов, дополни его
 2. Убери лишний мусорный текст и куски скриптов
 3. Переведи описание на русский язык
-4. Определи теги события
-5. Сгенерируй ссылку на Google Maps (если есть адрес)
-6. Сгенерируй ссылки на Google Calendar`,
+4. Выбери категорию события из списка и определи теги: жанры и темы, без повторения категории
+5. Сгенерируй ссылки на Google Calendar
+6. Если дата или цена не указаны, определи их из описания; если определить нельзя, верни пустые строки
+7. Если дата не указана и событие длится несколько дней или известно время окончания, укажи дату окончания
+8. Если цена не указана и билеты стоят по-разному, укажи максимальную цену; если вход бесплатный, отметь это`,
 		event.Name,
 		event.Description,
-		event.Date.Format("02.01.2006 15:04"),
-		event.Price,
-		event.Currency,
+		date,
+		price,
 		event.EventLink,
 	)
 
@@ -234,6 +326,10 @@ func (s *Openrouter) EnrichEventWithAI(ctx context.Context, logger *slog.Logger,
 				log.Error("GenerateSchemaForType error", sl.Err(err))
 				return dto.EventStructuredResponseSchema{}, fmt.Errorf("GenerateSchemaForType error: %w", err)
 			}
+			// Категорию AI выбирает только из контролируемого списка
+			category := schema.Properties["category"]
+			category.Enum = categoryEnum()
+			schema.Properties["category"] = category
 
 			r, e = s.Client.CreateChatCompletion(
 				ctx,
@@ -286,6 +382,16 @@ func (s *Openrouter) EnrichEventWithAI(ctx context.Context, logger *slog.Logger,
 	return responseSchema, nil
 }
 
+// categoryEnum возвращает коды категорий событий для JSON-схемы ответа AI.
+func categoryEnum() []string {
+	categories := domain.Categories()
+	result := make([]string, len(categories))
+	for i, c := range categories {
+		result[i] = string(c)
+	}
+	return result
+}
+
 // isRateLimitError проверяет, связана ли ошибка с превышением лимита запросов (HTTP 429).
 // Временное решение по анализу строки ошибки — менее надёжно, чем проверка кода.
 func isRateLimitError(err error) bool {
@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"sync"
+	"time"
 
 	"eventsBot/internal/config"
 	"eventsBot/internal/models/domain"
+	"eventsBot/internal/scheduler"
 
 	"github.com/google/uuid"
 )
@@ -30,6 +31,8 @@ type Repository interface {
 // TelegramBot определяет интерфейс для взаимодействия с Telegram ботом.
 type TelegramBot interface {
 	SendEvent(event *domain.Event, channelIDs []int64) error
+	NotifyEventCancelled(cancellation domain.EventCancellation, chatIDs []int64) error
+	NotifyScrapeHealth(alert domain.ScrapeRunAlert, chatIDs []int64) error
 }
 
 // Orchestrator управляет пайплайном: scraper → AI.
@@ -41,18 +44,29 @@ type Orchestrator struct {
 	repository          Repository
 	telegramBot         TelegramBot
 	completedEventsChan <-chan domain.Event
-	doneChans           []chan struct{}
-	mu                  sync.Mutex
+	cancelledEventsChan <-chan domain.EventCancellation
+	healthAlertsChan    <-chan domain.ScrapeRunAlert
+	scheduler           *scheduler.Scheduler
 	shutdownChan        chan struct{}
 }
 
 // New создаёт новый экземпляр Orchestrator.
-func New(logger *slog.Logger, cfg *config.Config, scraper Scraper, ai AI, repository Repository, telegramBot TelegramBot, completedEventsChan <-chan domain.Event) *Orchestrator {
+func New(
+	logger *slog.Logger,
+	cfg *config.Config,
+	scraper Scraper,
+	ai AI,
+	repository Repository,
+	telegramBot TelegramBot,
+	completedEventsChan <-chan domain.Event,
+	cancelledEventsChan <-chan domain.EventCancellation,
+	healthAlertsChan <-chan domain.ScrapeRunAlert,
+) *Orchestrator {
 	op := "Orchestrator.New()"
 	log := logger.With(slog.String("op", op))
 	log.Info("Creating orchestrator")
 
-	return &Orchestrator{
+	o := &Orchestrator{
 		logger:              logger,
 		cfg:                 cfg,
 		scraper:             scraper,
@@ -60,14 +74,50 @@ func New(logger *slog.Logger, cfg *config.Config, scraper Scraper, ai AI, reposi
 		repository:          repository,
 		telegramBot:         telegramBot,
 		completedEventsChan: completedEventsChan,
-		doneChans:           make([]chan struct{}, 0),
+		cancelledEventsChan: cancelledEventsChan,
+		healthAlertsChan:    healthAlertsChan,
 		shutdownChan:        make(chan struct{}),
 	}
+	o.scheduler = scheduler.New(logger, o.runScheduledSite)
+
+	// Регистрация расписаний сайтов из конфигурации
+	for _, site := range cfg.ScraperConfig.Sites {
+		spec := site.Schedule
+		if spec == "" {
+			spec = cfg.ScraperConfig.Schedule
+		}
+		jitter := site.Jitter
+		if jitter == 0 {
+			jitter = cfg.ScraperConfig.Jitter
+		}
+		// Расписание сайта с заданным часовым поясом считается по его местному времени
+		loc := time.Local
+		if site.TimezoneName() != "" {
+			siteLoc, err := site.Location()
+			if err != nil {
+				log.Error("failed to load site timezone, using local time",
+					slog.String("name", site.Name),
+					slog.String("error", err.Error()),
+				)
+			} else {
+				loc = siteLoc
+			}
+		}
+		if err := o.scheduler.Add(site.Name, spec, jitter, loc); err != nil {
+			log.Error("failed to schedule site",
+				slog.String("name", site.Name),
+				slog.String("schedule", spec),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return o
 }
 
 // Start запускает оркестратор.
 // Запускает горутину для обработки завершённых событий скрапера.
-// Также добавляет все сайты из конфигурации в очередь скрапера.
+// Также запускает планировщик, периодически добавляющий сайты из конфигурации в очередь скрапера.
 func (o *Orchestrator) Start() {
 	op := "Orchestrator.Start()"
 	log := o.logger.With(slog.String("op", op))
@@ -76,16 +126,24 @@ func (o *Orchestrator) Start() {
 	// Горутина слушает CompletedEventsChan от скрапера и отправляет в AI
 	go o.processScrapedEvents()
 
+	// Горутина слушает CancelledEventsChan от скрапера и уведомляет админов
+	go o.processCancelledEvents()
+
+	// Горутина слушает HealthAlertsChan от скрапера и уведомляет админов о поломке скраперов
+	go o.processHealthAlerts()
+
 	// Горутина проверяет в репозитории события в статусе NEW и отправляет в AI
 	go o.processNewEventsFromRepo()
 
 	// Горутина проверяет в репозитории события в статусе READY_TO_APPROVE и отправляет в Telegram
 	go o.SendEventsFromRepoToTelegram()
 
-	// Добавляем сайты из конфигурации в очередь скрапера
-	if err := o.EnqueueSites(); err != nil {
-		log.Error("failed to enqueue sites", slog.String("error", err.Error()))
+	// Запускаем скрапинг сайтов по расписанию
+	if len(o.cfg.ScraperConfig.Sites) == 0 {
+		log.Warn("no sites configured for scraping")
+		return
 	}
+	o.scheduler.Start(o.cfg.ScraperConfig.RunOnStart)
 }
 
 func (o *Orchestrator) SendEventsFromRepoToTelegram() {
@@ -98,7 +156,16 @@ func (o *Orchestrator) SendEventsFromRepoToTelegram() {
 		return
 	}
 
+	// Даты одного мероприятия публикуются одним сообщением: остальные даты серии перечисляются в нём
+	sentSeries := make(map[uuid.UUID]bool)
 	for _, event := range events {
+		if event.SeriesID != uuid.Nil {
+			if sentSeries[event.SeriesID] {
+				continue
+			}
+			sentSeries[event.SeriesID] = true
+		}
+
 		err := o.SendEventToTelegram(&event)
 		if err != nil {
 			log.Error("failed to send event to Telegram", slog.String("error", err.Error()))
@@ -119,6 +186,14 @@ func (o *Orchestrator) SendEventToTelegram(event *domain.Event) error {
 	return nil
 }
 
+// SendEventToAI отправляет событие в AI для обогащения (например, дубликат, отделённый модератором).
+func (o *Orchestrator) SendEventToAI(event *domain.Event) error {
+	if _, err := o.ai.AddJob(uuid.New(), *event); err != nil {
+		return fmt.Errorf("failed to add AI job: %w", err)
+	}
+	return nil
+}
+
 // processNewEventsFromRepo ищет все события в статусе NEW в репозитории и отправляет в AI
 func (o *Orchestrator) processNewEventsFromRepo() {
 	op := "Orchestrator.processNewEventsFromRepo()"
@@ -170,61 +245,104 @@ func (o *Orchestrator) processScrapedEvents() {
 	}
 }
 
-// EnqueueSites добавляет все сайты из конфигурации в очередь скрапера.
-func (o *Orchestrator) EnqueueSites() error {
-	op := "Orchestrator.EnqueueSites()"
+// processCancelledEvents слушает канал отменённых событий и уведомляет о них админов в Telegram.
+func (o *Orchestrator) processCancelledEvents() {
+	op := "Orchestrator.processCancelledEvents()"
 	log := o.logger.With(slog.String("op", op))
 
-	sites := o.cfg.ScraperConfig.Sites
-	if len(sites) == 0 {
-		log.Warn("no sites configured for scraping")
-		return nil
+	for {
+		select {
+		case <-o.shutdownChan:
+			log.Info("processCancelledEvents shutting down")
+			return
+		case cancellation, ok := <-o.cancelledEventsChan:
+			if !ok {
+				log.Info("cancelledEventsChan closed")
+				return
+			}
+
+			err := o.telegramBot.NotifyEventCancelled(cancellation, o.adminChatIDs())
+			if err != nil {
+				log.Error("failed to notify about cancelled event",
+					slog.String("eventID", cancellation.Event.ID.String()),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+
+			log.Debug("admins notified about cancelled event", slog.String("name", cancellation.Event.Name))
+		}
 	}
+}
 
-	log.Info("enqueueing sites for scraping", slog.Int("count", len(sites)))
+// processHealthAlerts слушает канал оповещений о работоспособности скраперов и пересылает их админам в Telegram.
+func (o *Orchestrator) processHealthAlerts() {
+	op := "Orchestrator.processHealthAlerts()"
+	log := o.logger.With(slog.String("op", op))
 
-	for _, site := range sites {
-		if err := o.AddJob(site.Name, site.URL); err != nil {
-			log.Error("failed to enqueue site",
-				slog.String("name", site.Name),
-				slog.String("url", site.URL),
-				slog.String("error", err.Error()),
+	for {
+		select {
+		case <-o.shutdownChan:
+			log.Info("processHealthAlerts shutting down")
+			return
+		case alert, ok := <-o.healthAlertsChan:
+			if !ok {
+				log.Info("healthAlertsChan closed")
+				return
+			}
+
+			err := o.telegramBot.NotifyScrapeHealth(alert, o.adminChatIDs())
+			if err != nil {
+				log.Error("failed to notify about scraper health",
+					slog.String("siteName", alert.Run.SiteName),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+
+			log.Debug("admins notified about scraper health",
+				slog.String("siteName", alert.Run.SiteName),
+				slog.String("status", string(alert.Run.Status)),
 			)
-			// Продолжаем добавлять остальные сайты
-			continue
 		}
-		log.Debug("site enqueued", slog.String("name", site.Name), slog.String("url", site.URL))
 	}
+}
 
-	return nil
+// adminChatIDs возвращает чаты для служебных уведомлений.
+func (o *Orchestrator) adminChatIDs() []int64 {
+	if len(o.cfg.BotConfig.AdminChatIDs) > 0 {
+		return o.cfg.BotConfig.AdminChatIDs
+	}
+	return o.cfg.BotConfig.ChannelIDs
 }
 
-// AddJob добавляет джобу в скрапер и сохраняет канал Done для ожидания.
-func (o *Orchestrator) AddJob(siteName string, url string) error {
-	op := "Orchestrator.AddJob()"
-	log := o.logger.With(slog.String("op", op))
+// runScheduledSite добавляет сайт в очередь скрапера по расписанию.
+// Возвращает канал, который закрывается по завершении скрапинга.
+func (o *Orchestrator) runScheduledSite(siteName string) (<-chan struct{}, error) {
+	op := "Orchestrator.runScheduledSite()"
+	log := o.logger.With(slog.String("op", op), slog.String("siteName", siteName))
 
-	requestID := uuid.New()
-	doneChan, err := o.scraper.AddJob(requestID, siteName, url)
-	if err != nil {
-		log.Error("failed to add job",
-			slog.String("siteName", siteName),
-			slog.String("url", url),
-			slog.String("error", err.Error()),
-		)
-		return err
-	}
+	for _, site := range o.cfg.ScraperConfig.Sites {
+		if site.Name != siteName {
+			continue
+		}
+
+		requestID := uuid.New()
+		doneChan, err := o.scraper.AddJob(requestID, site.Name, site.URL)
+		if err != nil {
+			return nil, err
+		}
 
-	o.mu.Lock()
-	o.doneChans = append(o.doneChans, doneChan)
-	o.mu.Unlock()
+		log.Debug("scheduled job added", slog.String("requestID", requestID.String()))
+		return doneChan, nil
+	}
 
-	log.Debug("job added",
-		slog.String("requestID", requestID.String()),
-		slog.String("siteName", siteName),
-	)
+	return nil, fmt.Errorf("site %s not found in config", siteName)
+}
 
-	return nil
+// ScheduleEntries возвращает время последнего и следующего скрапинга каждого сайта.
+func (o *Orchestrator) ScheduleEntries() []scheduler.EntryStatus {
+	return o.scheduler.Entries()
 }
 
 // Shutdown корректно завершает оркестратор.
@@ -234,6 +352,9 @@ func (o *Orchestrator) Shutdown(ctx context.Context) error {
 		case <-ctx.Done():
 			return fmt.Errorf("exit tgBot: %w", ctx.Err())
 		default:
+			if err := o.scheduler.Stop(ctx); err != nil {
+				return err
+			}
 			close(o.shutdownChan)
 			return nil
 		}
@@ -3,16 +3,70 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"eventsBot/internal/models/domain"
 	"eventsBot/internal/models/repositories"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
-func (r *Repository) CreateEvent(ctx context.Context, event domain.Event) (domain.Event, error) {
+// eventColumns — список колонок events, читаемых в repositories.Event.
+const eventColumns = `id, name, photo, image_hash, description, date, end_date, all_day, price, price_max, price_free, price_donation,
+	ticket_availability, currency, event_link, map_link, video_url,
+	calendar_link_ios, calendar_link_android, category, status, site_name, series_id, venue_id, canonical_id, duplicate_score,
+	source, source_fingerprint, changed_fields, created_at, updated_at,
+	ARRAY(SELECT t.name FROM event_tags et JOIN tags t ON t.id = et.tag_id
+		WHERE et.event_id = events.id ORDER BY et.position) AS tags`
+
+// sourceColumns — колонки events, которые заполняются из полей источника (см. domain.ApplySourceFields).
+// Дата не обновляется: по ссылке и дате событие и находится.
+var sourceColumns = []struct {
+	field   string
+	columns []string
+}{
+	{domain.SourceFieldName, []string{"name"}},
+	{domain.SourceFieldPhoto, []string{"photo", "image_hash"}},
+	{domain.SourceFieldDescription, []string{"description"}},
+	{domain.SourceFieldEndDate, []string{"end_date"}},
+	{domain.SourceFieldAllDay, []string{"all_day"}},
+	{domain.SourceFieldPrice, []string{"price"}},
+	{domain.SourceFieldPriceMax, []string{"price_max"}},
+	{domain.SourceFieldPriceFree, []string{"price_free"}},
+	{domain.SourceFieldDonation, []string{"price_donation"}},
+	{domain.SourceFieldAvailability, []string{"ticket_availability"}},
+	{domain.SourceFieldCurrency, []string{"currency"}},
+	{domain.SourceFieldMapLink, []string{"map_link"}},
+	{domain.SourceFieldVideoURL, []string{"video_url"}},
+}
+
+// upsertSourceSet — SET для ON CONFLICT в CreateEvent: колонка берётся из нового события, только если
+// соответствующее поле источника изменилось. У событий, сохранённых до появления отпечатков,
+// запоминается только снимок источника — как в Scraper.updateExisting.
+var upsertSourceSet = func() string {
+	var sb strings.Builder
+	for _, sc := range sourceColumns {
+		for _, column := range sc.columns {
+			fmt.Fprintf(&sb, `%[1]s = CASE WHEN events.source_fingerprint <> ''
+				AND COALESCE(EXCLUDED.source->>'%[2]s', '') <> COALESCE(events.source->>'%[2]s', '')
+				THEN EXCLUDED.%[1]s ELSE events.%[1]s END,
+			`, column, sc.field)
+		}
+	}
+	return sb.String()
+}()
+
+// CreateEvent сохраняет событие, найденное скрапером. Если событие с той же ссылкой и датой уже есть
+// (например, его только что сохранил другой воркер), обновляются поля, изменившиеся в источнике,
+// снимок источника и список изменившихся полей; статус, тег и правки модераторов не затрагиваются.
+// Возвращает сохранённое событие и итог: создано, обновлено или не изменилось.
+func (r *Repository) CreateEvent(ctx context.Context, event domain.Event) (domain.Event, domain.UpsertResult, error) {
 	op := "repository.CreateEvent()"
 
 	if event.ID == uuid.Nil {
@@ -21,13 +75,59 @@ func (r *Repository) CreateEvent(ctx context.Context, event domain.Event) (domai
 
 	repoEvent := mapToRepo(event)
 
-	insertQuery := `INSERT INTO events (
+	upsertQuery := `INSERT INTO events (
 		id, name, photo, description, date, price, currency, 
-		event_link, map_link, video_url, calendar_link_ios, calendar_link_android, tag, status,
-		created_at, updated_at
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+		event_link, map_link, video_url, calendar_link_ios, calendar_link_android, category, status,
+		site_name, source, source_fingerprint, changed_fields, end_date, all_day, series_id,
+		price_max, price_free, price_donation, ticket_availability, venue_id, image_hash, canonical_id, duplicate_score,
+		last_seen_at, created_at, updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21,
+		$22, $23, $24, $25, $26, $27, $28, $29, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	ON CONFLICT (event_link, date) DO UPDATE SET
+		` + upsertSourceSet + `changed_fields = CASE WHEN events.source_fingerprint = '' THEN '{}'::text[] ELSE ARRAY(
+			SELECT key FROM jsonb_each_text(EXCLUDED.source) AS n FULL JOIN jsonb_each_text(events.source) AS o USING (key)
+			WHERE COALESCE(n.value, '') <> COALESCE(o.value, '') ORDER BY key
+		) END,
+		source_changed_at = CASE WHEN events.source_fingerprint = '' THEN events.source_changed_at ELSE CURRENT_TIMESTAMP END,
+		source = EXCLUDED.source,
+		source_fingerprint = EXCLUDED.source_fingerprint,
+		updated_at = CURRENT_TIMESTAMP
+		WHERE events.source_fingerprint <> EXCLUDED.source_fingerprint
+	RETURNING ` + eventColumns + `, (xmax = 0) AS inserted`
+
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Event{}, "", fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	// SELECT ... FOR UPDATE не блокирует ещё не вставленную строку: без блокировки по идентичности
+	// источника два воркера, одновременно сохраняющие одно событие, оба прочитали бы пустое состояние,
+	// и проигравший записал бы в историю ложное создание
+	lockKey := repoEvent.EventLink + "|" + repoEvent.Date.UTC().Format(time.RFC3339Nano)
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, lockKey); err != nil {
+		return domain.Event{}, "", fmt.Errorf("%s: failed to lock event source identity: %w", op, err)
+	}
+
+	// Состояние уже сохранённого события с той же ссылкой и датой — для истории изменений
+	var before eventState
+	var existingID uuid.UUID
+	lockQuery := `SELECT id FROM events WHERE event_link = $1 AND date = $2 FOR UPDATE`
+	err = tx.GetContext(ctx, &existingID, lockQuery, repoEvent.EventLink, repoEvent.Date)
+	switch {
+	case err == nil:
+		if before, err = lockEventState(ctx, tx, existingID); err != nil {
+			return domain.Event{}, "", fmt.Errorf("%s: %w", op, err)
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return domain.Event{}, "", fmt.Errorf("%s: %w", op, err)
+	}
 
-	_, err := r.DB.ExecContext(ctx, insertQuery,
+	var saved struct {
+		repositories.Event
+		Inserted bool `db:"inserted"`
+	}
+	err = tx.GetContext(ctx, &saved, upsertQuery,
 		repoEvent.ID,
 		repoEvent.Name,
 		repoEvent.Photo,
@@ -40,25 +140,80 @@ func (r *Repository) CreateEvent(ctx context.Context, event domain.Event) (domai
 		repoEvent.VideoURL,
 		repoEvent.CalendarLinkIOS,
 		repoEvent.CalendarLinkAndroid,
-		repoEvent.Tag,
+		repoEvent.Category,
 		repoEvent.Status,
+		repoEvent.SiteName,
+		repoEvent.Source,
+		repoEvent.SourceFingerprint,
+		repoEvent.ChangedFields,
+		repoEvent.EndDate,
+		repoEvent.AllDay,
+		repoEvent.SeriesID,
+		repoEvent.PriceMax,
+		repoEvent.PriceFree,
+		repoEvent.PriceDonation,
+		repoEvent.TicketAvailability,
+		repoEvent.VenueID,
+		repoEvent.ImageHash,
+		repoEvent.CanonicalID,
+		repoEvent.DuplicateScore,
 	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Событие уже сохранено с тем же отпечатком источника: ON CONFLICT ничего не обновил
+		existing, err := r.FindEventByLinkAndDate(ctx, event.EventLink, event.Date)
+		if err != nil {
+			return domain.Event{}, "", fmt.Errorf("%s: %w", op, err)
+		}
+		return existing, domain.UpsertUnchanged, nil
+	}
 	if err != nil {
-		return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+		return domain.Event{}, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	return event, nil
+	// Новая дата обогащённой серии сразу получает её теги
+	if saved.Inserted && len(event.Tags) > 0 {
+		tags, err := setEventTags(ctx, tx, saved.ID, event.Category, event.Tags)
+		if err != nil {
+			return domain.Event{}, "", fmt.Errorf("%s: %w", op, err)
+		}
+		saved.Tags = tags
+	}
+
+	after, err := lockEventState(ctx, tx, saved.ID)
+	if err != nil {
+		return domain.Event{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if !saved.Inserted && before == nil {
+		return domain.Event{}, "", fmt.Errorf("%s: event %s was updated without a previous state", op, saved.ID)
+	}
+	// Upsert не меняет статус уже сохранённого события, поэтому в историю статусов пишется только создание
+	if saved.Inserted {
+		if err := recordStatusChange(ctx, tx, saved.ID, nil, after, "событие создано"); err != nil {
+			return domain.Event{}, "", fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err := saveRevision(ctx, tx, saved.ID, revisionAction(before, after), diffStates(before, after), uuid.Nil); err != nil {
+		return domain.Event{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.Event{}, "", fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	if saved.Inserted {
+		return mapToDomain(saved.Event), domain.UpsertCreated, nil
+	}
+	return mapToDomain(saved.Event), domain.UpsertUpdated, nil
 }
 
 func (r *Repository) FindEventByID(ctx context.Context, id uuid.UUID) (domain.Event, error) {
 	var repoEvent repositories.Event
-	query := `SELECT id, name, photo, description, date, price, currency, event_link, map_link, video_url, calendar_link_ios, calendar_link_android, tag, status, created_at, updated_at 
+	query := `SELECT ` + eventColumns + `
 	          FROM events WHERE id = $1 LIMIT 1`
 
 	err := r.DB.GetContext(ctx, &repoEvent, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return domain.Event{}, fmt.Errorf("event not found with id: %s", id)
+			return domain.Event{}, fmt.Errorf("%w with id: %s", domain.ErrEventNotFound, id)
 		}
 		return domain.Event{}, fmt.Errorf("error in FindEventByID(): %w", err)
 	}
@@ -68,7 +223,7 @@ func (r *Repository) FindEventByID(ctx context.Context, id uuid.UUID) (domain.Ev
 
 func (r *Repository) FindEventByLinkAndDate(ctx context.Context, link string, date time.Time) (domain.Event, error) {
 	var repoEvent repositories.Event
-	query := `SELECT id, name, photo, description, date, price, currency, event_link, map_link, video_url, calendar_link_ios, calendar_link_android, tag, status, created_at, updated_at 
+	query := `SELECT ` + eventColumns + `
 	          FROM events WHERE event_link = $1 AND date = $2 LIMIT 1`
 
 	err := r.DB.GetContext(ctx, &repoEvent, query, link, date)
@@ -82,62 +237,309 @@ func (r *Repository) FindEventByLinkAndDate(ctx context.Context, link string, da
 	return mapToDomain(repoEvent), nil
 }
 
+// FindEventByLink возвращает последнее созданное событие с указанной ссылкой.
+// Используется для источников без даты (например, RSS), где дата появляется только после AI.
+func (r *Repository) FindEventByLink(ctx context.Context, link string) (domain.Event, error) {
+	var repoEvent repositories.Event
+	query := `SELECT ` + eventColumns + `
+	          FROM events WHERE event_link = $1 ORDER BY created_at DESC LIMIT 1`
+
+	err := r.DB.GetContext(ctx, &repoEvent, query, link)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Event{}, fmt.Errorf("event not found with link: %s", link)
+		}
+		return domain.Event{}, fmt.Errorf("error in FindEventByLink(): %w", err)
+	}
+
+	return mapToDomain(repoEvent), nil
+}
+
+// FindEventsByLink возвращает все события с указанной ссылкой, отсортированные по дате.
+func (r *Repository) FindEventsByLink(ctx context.Context, link string) ([]domain.Event, error) {
+	var repoEvents []repositories.Event
+	query := `SELECT ` + eventColumns + `
+	          FROM events WHERE event_link = $1 ORDER BY date ASC`
+
+	err := r.DB.SelectContext(ctx, &repoEvents, query, link)
+	if err != nil {
+		return nil, fmt.Errorf("error in FindEventsByLink(): %w", err)
+	}
+
+	result := make([]domain.Event, len(repoEvents))
+	for i, e := range repoEvents {
+		result[i] = mapToDomain(e)
+	}
+
+	return result, nil
+}
+
+// FindUpcomingEventsByLink возвращает предстоящие события с указанной ссылкой, кроме отклонённых
+// и отменённых, отсортированные по дате. Прошедшие даты повторяющегося мероприятия не возвращаются,
+// чтобы новая дата на странице сохранялась отдельным событием, а не переписывала прошедшую.
+func (r *Repository) FindUpcomingEventsByLink(ctx context.Context, link string) ([]domain.Event, error) {
+	var repoEvents []repositories.Event
+	query := `SELECT ` + eventColumns + `
+	          FROM events
+	          WHERE event_link = $1 AND COALESCE(end_date, date) > now() AND status NOT IN ($2, $3)
+	          ORDER BY date ASC`
+
+	err := r.DB.SelectContext(ctx, &repoEvents, query, link,
+		string(domain.EventStatusRejected), string(domain.EventStatusCancelled))
+	if err != nil {
+		return nil, fmt.Errorf("error in FindUpcomingEventsByLink(): %w", err)
+	}
+
+	result := make([]domain.Event, len(repoEvents))
+	for i, e := range repoEvents {
+		result[i] = mapToDomain(e)
+	}
+
+	return result, nil
+}
+
 func (r *Repository) UpdateEvent(ctx context.Context, event domain.Event) (domain.Event, error) {
 	repoEvent := mapToRepo(event)
 
 	updateQuery := `UPDATE events SET 
 		name = $1, photo = $2, description = $3, date = $4, price = $5, currency = $6, 
-		event_link = $7, map_link = $8, video_url = $9, calendar_link_ios = $10, calendar_link_android = $11, tag = $12, status = $13,
+		event_link = $7, map_link = $8, video_url = $9, calendar_link_ios = $10, calendar_link_android = $11, category = $12, status = $13,
+		end_date = $15, all_day = $16,
+		price_max = $17, price_free = $18, price_donation = $19, ticket_availability = $20,
+		image_hash = CASE WHEN photo = $2 THEN image_hash ELSE NULL END,
 		updated_at = CURRENT_TIMESTAMP
 		WHERE id = $14`
 
-	result, err := r.DB.ExecContext(ctx, updateQuery,
-		repoEvent.Name,
-		repoEvent.Photo,
-		repoEvent.Description,
-		repoEvent.Date,
-		repoEvent.Price,
-		repoEvent.Currency,
-		repoEvent.EventLink,
-		repoEvent.MapLink,
-		repoEvent.VideoURL,
-		repoEvent.CalendarLinkIOS,
-		repoEvent.CalendarLinkAndroid,
-		repoEvent.Tag,
-		repoEvent.Status,
-		repoEvent.ID,
-	)
+	err := r.withRevision(ctx, event.ID, "событие обновлено", func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, updateQuery,
+			repoEvent.Name,
+			repoEvent.Photo,
+			repoEvent.Description,
+			repoEvent.Date,
+			repoEvent.Price,
+			repoEvent.Currency,
+			repoEvent.EventLink,
+			repoEvent.MapLink,
+			repoEvent.VideoURL,
+			repoEvent.CalendarLinkIOS,
+			repoEvent.CalendarLinkAndroid,
+			repoEvent.Category,
+			repoEvent.Status,
+			repoEvent.ID,
+			repoEvent.EndDate,
+			repoEvent.AllDay,
+			repoEvent.PriceMax,
+			repoEvent.PriceFree,
+			repoEvent.PriceDonation,
+			repoEvent.TicketAvailability,
+		)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error checking rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("event with id %s not found", event.ID)
+		}
+
+		event.Tags, err = setEventTags(ctx, tx, event.ID, event.Category, event.Tags)
+		return err
+	})
 	if err != nil {
 		return domain.Event{}, fmt.Errorf("error in UpdateEvent(): %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return event, nil
+}
+
+// UpdateEventFromSource сохраняет изменения, найденные при повторном скрапинге:
+// поля события, статус, новый снимок источника и список изменившихся полей.
+// Поля, которые заполняют AI и модераторы (категория, теги, ссылки на календари), не затрагиваются.
+func (r *Repository) UpdateEventFromSource(ctx context.Context, event domain.Event) (domain.Event, error) {
+	op := "repository.UpdateEventFromSource()"
+
+	repoEvent := mapToRepo(event)
+
+	updateQuery := `UPDATE events SET 
+		name = $1, photo = $2, description = $3, date = $4, price = $5, currency = $6,
+		map_link = $7, video_url = $8, status = $9,
+		source = $10, source_fingerprint = $11, changed_fields = $12, end_date = $14, all_day = $15,
+		price_max = $16, price_free = $17, price_donation = $18, ticket_availability = $19, image_hash = $20,
+		source_changed_at = CASE WHEN cardinality($12::text[]) > 0 THEN CURRENT_TIMESTAMP ELSE source_changed_at END,
+		updated_at = CURRENT_TIMESTAMP
+		WHERE id = $13`
+
+	err := r.withRevision(ctx, event.ID, "источник изменился", func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, updateQuery,
+			repoEvent.Name,
+			repoEvent.Photo,
+			repoEvent.Description,
+			repoEvent.Date,
+			repoEvent.Price,
+			repoEvent.Currency,
+			repoEvent.MapLink,
+			repoEvent.VideoURL,
+			repoEvent.Status,
+			repoEvent.Source,
+			repoEvent.SourceFingerprint,
+			repoEvent.ChangedFields,
+			repoEvent.ID,
+			repoEvent.EndDate,
+			repoEvent.AllDay,
+			repoEvent.PriceMax,
+			repoEvent.PriceFree,
+			repoEvent.PriceDonation,
+			repoEvent.TicketAvailability,
+			repoEvent.ImageHash,
+		)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("event not found with id %s", event.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return event, nil
+}
+
+// MarkEventsSeen отмечает события как найденные на сайте siteName при последнем скрапинге
+// и сбрасывает счётчик пропусков.
+func (r *Repository) MarkEventsSeen(ctx context.Context, siteName string, ids []uuid.UUID) error {
+	op := "repository.MarkEventsSeen()"
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	updateQuery := `UPDATE events SET site_name = $1, last_seen_at = CURRENT_TIMESTAMP, missed_scrapes = 0
+		WHERE id = ANY($2::uuid[])`
+
+	_, err := r.DB.ExecContext(ctx, updateQuery, siteName, uuidArray(ids))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MarkEventsChecked запоминает, что страницы событий были только что скачаны и разобраны.
+func (r *Repository) MarkEventsChecked(ctx context.Context, ids []uuid.UUID) error {
+	op := "repository.MarkEventsChecked()"
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	updateQuery := `UPDATE events SET source_checked_at = CURRENT_TIMESTAMP WHERE id = ANY($1::uuid[])`
+
+	_, err := r.DB.ExecContext(ctx, updateQuery, uuidArray(ids))
 	if err != nil {
-		return domain.Event{}, fmt.Errorf("error checking rows affected: %w", err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if rowsAffected == 0 {
-		return domain.Event{}, fmt.Errorf("event with id %s not found", event.ID)
+	return nil
+}
+
+// IsEventCheckedSince проверяет, скачивалась ли страница события по ссылке link после since.
+func (r *Repository) IsEventCheckedSince(ctx context.Context, link string, since time.Time) (bool, error) {
+	op := "repository.IsEventCheckedSince()"
+
+	var checked bool
+	query := `SELECT EXISTS (SELECT 1 FROM events WHERE event_link = $1 AND source_checked_at > $2)`
+
+	if err := r.DB.GetContext(ctx, &checked, query, link, since); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return event, nil
+	return checked, nil
 }
 
-func (r *Repository) DeleteEvent(ctx context.Context, id uuid.UUID) error {
-	deleteQuery := `DELETE FROM events WHERE id = $1`
+// FindMissingEvents возвращает предстоящие и идущие события сайта siteName, которых нет среди seen.
+// Отклонённые, уже отменённые события и дубликаты не возвращаются.
+func (r *Repository) FindMissingEvents(ctx context.Context, siteName string, seen []uuid.UUID) ([]domain.Event, error) {
+	op := "repository.FindMissingEvents()"
 
-	result, err := r.DB.ExecContext(ctx, deleteQuery, id)
+	var repoEvents []repositories.Event
+	query := `SELECT ` + eventColumns + `
+	          FROM events
+	          WHERE site_name = $1 AND NOT (id = ANY($2::uuid[]))
+	            AND status NOT IN ($3, $4, $5) AND COALESCE(end_date, date) > CURRENT_TIMESTAMP
+	          ORDER BY date ASC`
+
+	err := r.DB.SelectContext(ctx, &repoEvents, query,
+		siteName,
+		uuidArray(seen),
+		string(domain.EventStatusRejected),
+		string(domain.EventStatusCancelled),
+		string(domain.EventStatusDuplicate),
+	)
 	if err != nil {
-		return fmt.Errorf("error in DeleteEvent(): %w", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.Event, len(repoEvents))
+	for i, e := range repoEvents {
+		result[i] = mapToDomain(e)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return result, nil
+}
+
+// IncrementMissedScrapes увеличивает счётчик скрапингов подряд, в которых событие не найдено,
+// и возвращает его новое значение.
+func (r *Repository) IncrementMissedScrapes(ctx context.Context, eventID uuid.UUID) (int, error) {
+	op := "repository.IncrementMissedScrapes()"
+
+	var missed int
+	updateQuery := `UPDATE events SET missed_scrapes = missed_scrapes + 1 WHERE id = $1 RETURNING missed_scrapes`
+
+	err := r.DB.GetContext(ctx, &missed, updateQuery, eventID)
 	if err != nil {
-		return fmt.Errorf("error checking rows affected: %w", err)
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("%s: event not found with id %s", op, eventID)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("event with id %s not found", id)
+	return missed, nil
+}
+
+func (r *Repository) DeleteEvent(ctx context.Context, id uuid.UUID) error {
+	deleteQuery := `DELETE FROM events WHERE id = $1`
+
+	err := r.withRevision(ctx, id, "", func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, deleteQuery, id)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error checking rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("event with id %s not found", id)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error in DeleteEvent(): %w", err)
 	}
 
 	return nil
@@ -145,7 +547,7 @@ func (r *Repository) DeleteEvent(ctx context.Context, id uuid.UUID) error {
 
 func (r *Repository) ReadAllEvents(ctx context.Context) ([]domain.Event, error) {
 	var repoEvents []repositories.Event
-	query := `SELECT id, name, photo, description, date, price, currency, event_link, map_link, video_url, calendar_link_ios, calendar_link_android, tag, status, created_at, updated_at 
+	query := `SELECT ` + eventColumns + `
 	          FROM events ORDER BY date ASC`
 
 	err := r.DB.SelectContext(ctx, &repoEvents, query)
@@ -164,7 +566,7 @@ func (r *Repository) ReadAllEvents(ctx context.Context) ([]domain.Event, error)
 // FindEventsByStatus возвращает список событий с указанным статусом.
 func (r *Repository) FindEventsByStatus(ctx context.Context, status domain.EventStatus) ([]domain.Event, error) {
 	var repoEvents []repositories.Event
-	query := `SELECT id, name, photo, description, date, price, currency, event_link, map_link, video_url, calendar_link_ios, calendar_link_android, tag, status, created_at, updated_at 
+	query := `SELECT ` + eventColumns + `
 	          FROM events WHERE status = $1 ORDER BY date ASC`
 
 	err := r.DB.SelectContext(ctx, &repoEvents, query, string(status))
@@ -187,17 +589,32 @@ func mapToRepo(e domain.Event) repositories.Event {
 		},
 		Name:                e.Name,
 		Photo:               e.Photo,
+		ImageHash:           sql.NullString{String: e.ImageHash, Valid: e.ImageHash != ""},
 		Description:         e.Description,
 		Date:                e.Date,
-		Price:               e.Price,
-		Currency:            e.Currency,
+		EndDate:             sql.NullTime{Time: e.EndDate, Valid: !e.EndDate.IsZero()},
+		AllDay:              e.AllDay,
+		Price:               e.Price.Min,
+		PriceMax:            e.Price.Max,
+		PriceFree:           e.Price.Free,
+		PriceDonation:       e.Price.Donation,
+		TicketAvailability:  string(e.Price.Availability),
+		Currency:            e.Price.Currency,
 		EventLink:           e.EventLink,
 		MapLink:             e.MapLink,
 		VideoURL:            e.VideoURL,
 		CalendarLinkIOS:     e.CalendarLinkIOS,
 		CalendarLinkAndroid: e.CalendarLinkAndroid,
-		Tag:                 e.Tag,
+		Category:            string(e.Category),
 		Status:              string(e.Status),
+		SiteName:            e.SiteName,
+		SeriesID:            uuid.NullUUID{UUID: e.SeriesID, Valid: e.SeriesID != uuid.Nil},
+		VenueID:             uuid.NullUUID{UUID: e.VenueID, Valid: e.VenueID != uuid.Nil},
+		CanonicalID:         uuid.NullUUID{UUID: e.CanonicalID, Valid: e.CanonicalID != uuid.Nil},
+		DuplicateScore:      e.DuplicateScore,
+		Source:              marshalSource(e.Source),
+		SourceFingerprint:   e.Source.Fingerprint(),
+		ChangedFields:       textArray(e.ChangedFields),
 	}
 }
 
@@ -206,38 +623,109 @@ func mapToDomain(e repositories.Event) domain.Event {
 		ID:                  e.ID,
 		Name:                e.Name,
 		Photo:               e.Photo,
+		ImageHash:           e.ImageHash.String,
 		Description:         e.Description,
 		Date:                e.Date,
-		Price:               e.Price,
-		Currency:            e.Currency,
+		EndDate:             e.EndDate.Time,
+		AllDay:              e.AllDay,
+		Price:               mapPriceToDomain(e),
 		EventLink:           e.EventLink,
 		MapLink:             e.MapLink,
 		VideoURL:            e.VideoURL,
 		CalendarLinkIOS:     e.CalendarLinkIOS,
 		CalendarLinkAndroid: e.CalendarLinkAndroid,
-		Tag:                 e.Tag,
+		Category:            domain.Category(e.Category),
+		Tags:                []string(e.Tags),
 		Status:              domain.EventStatus(e.Status),
+		SiteName:            e.SiteName,
+		SeriesID:            e.SeriesID.UUID,
+		VenueID:             e.VenueID.UUID,
+		CanonicalID:         e.CanonicalID.UUID,
+		DuplicateScore:      e.DuplicateScore,
+		Source:              unmarshalSource(e.Source),
+		ChangedFields:       []string(e.ChangedFields),
 	}
 }
 
-// UpdateEventStatus обновляет статус события по его ID.
-func (r *Repository) UpdateEventStatus(ctx context.Context, eventID uuid.UUID, status string) error {
-	op := "repository.UpdateEventStatus()"
+// mapPriceToDomain собирает структурированную цену из колонок цены события.
+func mapPriceToDomain(e repositories.Event) domain.Price {
+	return domain.Price{
+		Min:          e.Price,
+		Max:          e.PriceMax,
+		Currency:     e.Currency,
+		Free:         e.PriceFree,
+		Donation:     e.PriceDonation,
+		Availability: domain.TicketAvailability(e.TicketAvailability),
+	}
+}
 
-	updateQuery := `UPDATE events SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+// textArray преобразует слайс строк для колонок TEXT[] NOT NULL (changed_fields, tags серии):
+// nil-слайс pq передаёт как NULL.
+func textArray(fields []string) pq.StringArray {
+	if fields == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(fields)
+}
 
-	result, err := r.DB.ExecContext(ctx, updateQuery, status, eventID)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+// uuidArray преобразует идентификаторы в массив строк для параметров вида $1::uuid[].
+func uuidArray(ids []uuid.UUID) pq.StringArray {
+	result := make(pq.StringArray, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
 	}
+	return result
+}
 
-	rowsAffected, err := result.RowsAffected()
+// marshalSource сериализует снимок источника в JSON для колонки source.
+func marshalSource(s domain.SourceSnapshot) string {
+	if len(s) == 0 {
+		return "{}"
+	}
+	data, err := json.Marshal(s)
 	if err != nil {
-		return fmt.Errorf("%s: failed to get rows affected: %w", op, err)
+		return "{}"
 	}
+	return string(data)
+}
+
+// unmarshalSource разбирает колонку source. Некорректный JSON даёт пустой снимок.
+func unmarshalSource(data string) domain.SourceSnapshot {
+	var s domain.SourceSnapshot
+	if data == "" {
+		return s
+	}
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		return nil
+	}
+	return s
+}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("%s: event not found with id %s", op, eventID)
+// UpdateEventStatus переводит событие в статус status по таблице переходов domain и записывает
+// переход с причиной reason в историю статусов. Недопустимый переход возвращает *domain.TransitionError.
+func (r *Repository) UpdateEventStatus(ctx context.Context, eventID uuid.UUID, status string, reason string) error {
+	op := "repository.UpdateEventStatus()"
+
+	updateQuery := `UPDATE events SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	err := r.withRevision(ctx, eventID, reason, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, updateQuery, status, eventID)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("event not found with id %s", eventID)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/models/repositories"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// FindDuplicateCandidates возвращает события с другой ссылкой, начинающиеся в пределах ±window
+// от начала event, — кандидатов в канонические события для event. Дубликаты и отменённые
+// события не возвращаются; отклонённые возвращаются, чтобы их копии с других сайтов
+// не попадали на модерацию повторно.
+func (r *Repository) FindDuplicateCandidates(ctx context.Context, event domain.Event, window time.Duration) ([]domain.Event, error) {
+	op := "repository.FindDuplicateCandidates()"
+
+	var repoEvents []repositories.Event
+	query := `SELECT ` + eventColumns + `
+	          FROM events
+	          WHERE event_link <> $1 AND date BETWEEN $2 AND $3
+	            AND canonical_id IS NULL AND status NOT IN ($4, $5)
+	          ORDER BY created_at ASC`
+
+	err := r.DB.SelectContext(ctx, &repoEvents, query,
+		event.EventLink,
+		event.Date.Add(-window),
+		event.Date.Add(window),
+		string(domain.EventStatusDuplicate),
+		string(domain.EventStatusCancelled),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.Event, len(repoEvents))
+	for i, e := range repoEvents {
+		result[i] = mapToDomain(e)
+	}
+
+	return result, nil
+}
+
+// FindEventDuplicates возвращает дубликаты канонического события — то же мероприятие на других сайтах.
+func (r *Repository) FindEventDuplicates(ctx context.Context, canonicalID uuid.UUID) ([]domain.Event, error) {
+	op := "repository.FindEventDuplicates()"
+
+	var repoEvents []repositories.Event
+	query := `SELECT ` + eventColumns + `
+	          FROM events WHERE canonical_id = $1 AND status = $2 ORDER BY created_at ASC`
+
+	err := r.DB.SelectContext(ctx, &repoEvents, query, canonicalID, string(domain.EventStatusDuplicate))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.Event, len(repoEvents))
+	for i, e := range repoEvents {
+		result[i] = mapToDomain(e)
+	}
+
+	return result, nil
+}
+
+// SplitDuplicate отделяет ошибочно объединённый дубликат от канонического события:
+// событие становится самостоятельным и возвращается в статус NEW для обогащения AI.
+func (r *Repository) SplitDuplicate(ctx context.Context, id uuid.UUID) (domain.Event, error) {
+	op := "repository.SplitDuplicate()"
+
+	var repoEvent repositories.Event
+	updateQuery := `UPDATE events SET canonical_id = NULL, duplicate_score = 0, status = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND status = $3
+		RETURNING ` + eventColumns
+
+	err := r.withRevision(ctx, id, "дубликат отделён", func(tx *sqlx.Tx) error {
+		return tx.GetContext(ctx, &repoEvent, updateQuery,
+			string(domain.EventStatusNew),
+			id,
+			string(domain.EventStatusDuplicate),
+		)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Event{}, fmt.Errorf("%s: duplicate event not found with id %s", op, id)
+		}
+		return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapToDomain(repoEvent), nil
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/models/repositories"
+
+	"github.com/google/uuid"
+)
+
+// SaveEventPost сохраняет сообщение Telegram, в котором опубликовано событие.
+func (r *Repository) SaveEventPost(ctx context.Context, post domain.EventPost) error {
+	op := "repository.SaveEventPost()"
+
+	insertQuery := `INSERT INTO event_posts (event_id, chat_id, message_id, is_photo, created_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		ON CONFLICT (chat_id, message_id) DO NOTHING`
+
+	_, err := r.DB.ExecContext(ctx, insertQuery, post.EventID, post.ChatID, post.MessageID, post.IsPhoto)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FindEventPosts возвращает все сообщения Telegram, в которых опубликовано событие.
+func (r *Repository) FindEventPosts(ctx context.Context, eventID uuid.UUID) ([]domain.EventPost, error) {
+	op := "repository.FindEventPosts()"
+
+	var repoPosts []repositories.EventPost
+	query := `SELECT event_id, chat_id, message_id, is_photo, created_at
+	          FROM event_posts WHERE event_id = $1 ORDER BY created_at ASC`
+
+	err := r.DB.SelectContext(ctx, &repoPosts, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.EventPost, len(repoPosts))
+	for i, p := range repoPosts {
+		result[i] = mapEventPostToDomain(p)
+	}
+
+	return result, nil
+}
+
+// DeleteEventPost удаляет запись о сообщении Telegram (после удаления самого сообщения).
+func (r *Repository) DeleteEventPost(ctx context.Context, chatID int64, messageID int) error {
+	op := "repository.DeleteEventPost()"
+
+	deleteQuery := `DELETE FROM event_posts WHERE chat_id = $1 AND message_id = $2`
+
+	_, err := r.DB.ExecContext(ctx, deleteQuery, chatID, messageID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func mapEventPostToDomain(p repositories.EventPost) domain.EventPost {
+	return domain.EventPost{
+		EventID:   p.EventID,
+		ChatID:    p.ChatID,
+		MessageID: p.MessageID,
+		IsPhoto:   p.IsPhoto,
+	}
+}
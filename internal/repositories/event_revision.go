@@ -0,0 +1,372 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/models/repositories"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation — код ошибки PostgreSQL при нарушении уникального индекса.
+const uniqueViolation = "23505"
+
+// revisionColumns — колонки events, изменения которых записываются в историю.
+// Служебные колонки (снимок источника, счётчики скрапинга, время изменения) не записываются.
+var revisionColumns = []string{
+	"name", "photo", "image_hash", "description", "date", "end_date", "all_day",
+	"price", "price_max", "price_free", "price_donation", "ticket_availability", "currency",
+	"event_link", "map_link", "video_url", "calendar_link_ios", "calendar_link_android",
+	"category", "tags", "status", "series_id", "venue_id", "canonical_id", "duplicate_score",
+}
+
+// notRevertedColumns — колонки, которые не возвращаются при откате к ревизии:
+// статус меняется только модерацией и публикацией, связь с каноническим событием —
+// только дедупликацией и разделением, серия — только скрапером.
+var notRevertedColumns = map[string]bool{
+	"status":          true,
+	"canonical_id":    true,
+	"duplicate_score": true,
+	"series_id":       true,
+}
+
+// eventState — значения revisionColumns события в текстовом виде БД; nil — NULL.
+type eventState map[string]*string
+
+// revisionChange — изменение поля в колонке event_revisions.changes.
+type revisionChange struct {
+	Old *string `json:"old"`
+	New *string `json:"new"`
+}
+
+// withRevision выполняет изменение события eventID в транзакции и записывает в историю разницу
+// полей события до и после изменения от имени автора из ctx (domain.ActorFromContext).
+// Если изменился статус, переход проверяется и записывается в историю статусов с причиной reason;
+// недопустимый переход отменяет изменение (*domain.TransitionError). Ошибки mutate возвращаются без изменений.
+func (r *Repository) withRevision(ctx context.Context, eventID uuid.UUID, reason string, mutate func(tx *sqlx.Tx) error) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockEventState(ctx, tx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if err := mutate(tx); err != nil {
+		return err
+	}
+
+	after, err := lockEventState(ctx, tx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if err := recordStatusChange(ctx, tx, eventID, before, after, reason); err != nil {
+		return err
+	}
+	if err := saveRevision(ctx, tx, eventID, revisionAction(before, after), diffStates(before, after), uuid.Nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// lockEventState блокирует событие до конца транзакции и возвращает его состояние.
+// Теги события записываются JSON-массивом в поле "tags". Если события нет, возвращает nil.
+func lockEventState(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID) (eventState, error) {
+	var data string
+	query := `SELECT (to_jsonb(e) || jsonb_build_object('tags', ARRAY(
+			SELECT t.name FROM event_tags et JOIN tags t ON t.id = et.tag_id
+			WHERE et.event_id = e.id ORDER BY et.position
+		)))::text
+		FROM events e WHERE e.id = $1 FOR UPDATE`
+
+	err := tx.GetContext(ctx, &data, query, eventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event state: %w", err)
+	}
+
+	var row map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &row); err != nil {
+		return nil, fmt.Errorf("failed to decode event state: %w", err)
+	}
+
+	state := make(eventState, len(revisionColumns))
+	for _, column := range revisionColumns {
+		raw, ok := row[column]
+		if !ok || string(raw) == "null" {
+			state[column] = nil
+			continue
+		}
+		// Строки и даты приходят в кавычках, числа и логические значения — как есть
+		value := string(raw)
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			value = s
+		}
+		state[column] = &value
+	}
+	return state, nil
+}
+
+// revisionAction определяет вид изменения по состояниям события до и после него.
+func revisionAction(before, after eventState) domain.RevisionAction {
+	switch {
+	case before == nil:
+		return domain.RevisionCreated
+	case after == nil:
+		return domain.RevisionDeleted
+	default:
+		return domain.RevisionUpdated
+	}
+}
+
+// diffStates возвращает изменившиеся поля события. При создании и удалении события
+// пустые значения не записываются.
+func diffStates(before, after eventState) map[string]revisionChange {
+	changes := make(map[string]revisionChange)
+	for _, column := range revisionColumns {
+		oldValue, newValue := before[column], after[column]
+		if equalValues(oldValue, newValue) {
+			continue
+		}
+		if (before == nil && isEmptyValue(newValue)) || (after == nil && isEmptyValue(oldValue)) {
+			continue
+		}
+		changes[column] = revisionChange{Old: oldValue, New: newValue}
+	}
+	return changes
+}
+
+func equalValues(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func isEmptyValue(v *string) bool {
+	return v == nil || *v == "" || *v == "0" || *v == "false" || *v == "[]"
+}
+
+// saveRevision записывает ревизию события, если в ней есть изменения.
+func saveRevision(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID, action domain.RevisionAction, changes map[string]revisionChange, revertedTo uuid.UUID) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode revision: %w", err)
+	}
+
+	actor := domain.ActorFromContext(ctx)
+	insertQuery := `INSERT INTO event_revisions (id, event_id, action, actor_type, actor_id, changes, reverted_to)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = tx.ExecContext(ctx, insertQuery,
+		uuid.New(),
+		eventID,
+		string(action),
+		string(actor.Type),
+		actor.ID,
+		string(data),
+		uuid.NullUUID{UUID: revertedTo, Valid: revertedTo != uuid.Nil},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save revision: %w", err)
+	}
+	return nil
+}
+
+// FindEventRevisions возвращает историю изменений события от старых ревизий к новым.
+func (r *Repository) FindEventRevisions(ctx context.Context, eventID uuid.UUID) ([]domain.EventRevision, error) {
+	op := "repository.FindEventRevisions()"
+
+	var repoRevisions []repositories.EventRevision
+	query := `SELECT id, event_id, action, actor_type, actor_id, changes, reverted_to, created_at
+	          FROM event_revisions WHERE event_id = $1 ORDER BY created_at ASC`
+
+	if err := r.DB.SelectContext(ctx, &repoRevisions, query, eventID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.EventRevision, len(repoRevisions))
+	for i, rev := range repoRevisions {
+		result[i] = mapRevisionToDomain(rev)
+	}
+
+	return result, nil
+}
+
+// RevertEvent возвращает поля события к состоянию сразу после ревизии revisionID: каждое поле,
+// изменённое более поздними ревизиями, получает значение, которое было до первого из этих изменений.
+// Статус, связь с каноническим событием и серия не возвращаются (см. notRevertedColumns).
+// Если восстановленные ссылка и дата заняты другим событием, возвращается domain.ErrRevisionConflict.
+// Откат записывается в историю как отдельная ревизия.
+func (r *Repository) RevertEvent(ctx context.Context, eventID uuid.UUID, revisionID uuid.UUID) (domain.Event, error) {
+	op := "repository.RevertEvent()"
+
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Event{}, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockEventState(ctx, tx, eventID)
+	if err != nil {
+		return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if before == nil {
+		return domain.Event{}, fmt.Errorf("%s: %w with id %s", op, domain.ErrEventNotFound, eventID)
+	}
+
+	var revisionEventID uuid.UUID
+	revisionQuery := `SELECT event_id FROM event_revisions WHERE id = $1`
+	if err := tx.GetContext(ctx, &revisionEventID, revisionQuery, revisionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Event{}, fmt.Errorf("%s: %w with id %s", op, domain.ErrRevisionNotFound, revisionID)
+		}
+		return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if revisionEventID != eventID {
+		return domain.Event{}, fmt.Errorf("%s: %w: revision %s, event %s", op, domain.ErrRevisionOfOtherEvent, revisionID, eventID)
+	}
+
+	var later []repositories.EventRevision
+	query := `SELECT id, event_id, action, actor_type, actor_id, changes, reverted_to, created_at
+	          FROM event_revisions
+	          WHERE event_id = $1 AND created_at > (SELECT created_at FROM event_revisions WHERE id = $2)
+	          ORDER BY created_at ASC`
+
+	if err := tx.SelectContext(ctx, &later, query, eventID, revisionID); err != nil {
+		return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Для каждого поля берём значение до первого изменения после целевой ревизии
+	restore := make(map[string]*string)
+	for _, rev := range later {
+		for column, change := range unmarshalChanges(rev.Changes) {
+			if _, ok := restore[column]; ok || notRevertedColumns[column] {
+				continue
+			}
+			restore[column] = change.Old
+		}
+	}
+
+	var sets []string
+	var args []any
+	for _, column := range revisionColumns {
+		value, ok := restore[column]
+		if !ok || column == "tags" || equalValues(value, before[column]) {
+			continue
+		}
+		args = append(args, sql.NullString{String: deref(value), Valid: value != nil})
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	// Теги хранятся в event_tags и восстанавливаются отдельно
+	tags, restoreTags := restore["tags"]
+	restoreTags = restoreTags && !equalValues(tags, before["tags"])
+
+	if len(sets) > 0 || restoreTags {
+		if len(sets) > 0 {
+			args = append(args, eventID)
+			updateQuery := `UPDATE events SET ` + strings.Join(sets, ", ") + `, updated_at = CURRENT_TIMESTAMP
+				WHERE id = $` + fmt.Sprint(len(args))
+			if _, err := tx.ExecContext(ctx, updateQuery, args...); err != nil {
+				// Восстановленные ссылка и дата уже заняты другим событием (idx_events_source_identity)
+				var pqErr *pq.Error
+				if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+					return domain.Event{}, fmt.Errorf("%s: %w: %s", op, domain.ErrRevisionConflict, pqErr.Message)
+				}
+				return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		if restoreTags {
+			var names []string
+			if tags != nil {
+				if err := json.Unmarshal([]byte(*tags), &names); err != nil {
+					return domain.Event{}, fmt.Errorf("%s: failed to decode revision tags: %w", op, err)
+				}
+			}
+			category := before["category"]
+			if value, ok := restore["category"]; ok {
+				category = value
+			}
+			if _, err := setEventTags(ctx, tx, eventID, domain.Category(deref(category)), names); err != nil {
+				return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		after, err := lockEventState(ctx, tx, eventID)
+		if err != nil {
+			return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := saveRevision(ctx, tx, eventID, domain.RevisionReverted, diffStates(before, after), revisionID); err != nil {
+			return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	var repoEvent repositories.Event
+	if err := tx.GetContext(ctx, &repoEvent, `SELECT `+eventColumns+` FROM events WHERE id = $1`, eventID); err != nil {
+		return domain.Event{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.Event{}, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return mapToDomain(repoEvent), nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func unmarshalChanges(data string) map[string]revisionChange {
+	changes := make(map[string]revisionChange)
+	if data == "" {
+		return changes
+	}
+	_ = json.Unmarshal([]byte(data), &changes)
+	return changes
+}
+
+func mapRevisionToDomain(rev repositories.EventRevision) domain.EventRevision {
+	changes := make(map[string]domain.FieldChange)
+	for column, change := range unmarshalChanges(rev.Changes) {
+		changes[column] = domain.FieldChange{Old: change.Old, New: change.New}
+	}
+
+	return domain.EventRevision{
+		ID:         rev.ID,
+		EventID:    rev.EventID,
+		Action:     domain.RevisionAction(rev.Action),
+		Actor:      domain.Actor{Type: domain.ActorType(rev.ActorType), ID: rev.ActorID},
+		Changes:    changes,
+		RevertedTo: rev.RevertedTo.UUID,
+		CreatedAt:  rev.CreatedAt,
+	}
+}
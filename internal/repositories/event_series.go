@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/models/repositories"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// seriesColumns — список колонок таблицы event_series для SELECT.
+const seriesColumns = `id, site_name, event_link, name, description, photo, map_link, category, tags, enriched_at,
+	created_at, updated_at`
+
+// FindOrCreateSeries возвращает серию с той же ссылкой на мероприятие, что и series,
+// а если её нет — сохраняет series как новую.
+func (r *Repository) FindOrCreateSeries(ctx context.Context, series domain.EventSeries) (domain.EventSeries, error) {
+	op := "repository.FindOrCreateSeries()"
+
+	if series.ID == uuid.Nil {
+		series.ID = uuid.New()
+	}
+
+	// DO UPDATE вместо DO NOTHING, чтобы RETURNING вернул и уже существующую серию
+	query := `INSERT INTO event_series (id, site_name, event_link, name, photo, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (event_link) DO UPDATE SET updated_at = event_series.updated_at
+		RETURNING ` + seriesColumns
+
+	var repoSeries repositories.EventSeries
+	err := r.DB.GetContext(ctx, &repoSeries, query,
+		series.ID, series.SiteName, series.EventLink, series.Name, series.Photo,
+	)
+	if err != nil {
+		return domain.EventSeries{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapSeriesToDomain(repoSeries), nil
+}
+
+// FindSeriesByID возвращает серию по её ID.
+func (r *Repository) FindSeriesByID(ctx context.Context, id uuid.UUID) (domain.EventSeries, error) {
+	op := "repository.FindSeriesByID()"
+
+	var repoSeries repositories.EventSeries
+	query := `SELECT ` + seriesColumns + ` FROM event_series WHERE id = $1`
+
+	err := r.DB.GetContext(ctx, &repoSeries, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.EventSeries{}, fmt.Errorf("%s: series not found with id %s", op, id)
+		}
+		return domain.EventSeries{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapSeriesToDomain(repoSeries), nil
+}
+
+// SaveSeriesContent сохраняет общее содержимое серии после обогащения AI и отмечает серию обогащённой.
+func (r *Repository) SaveSeriesContent(ctx context.Context, series domain.EventSeries) error {
+	op := "repository.SaveSeriesContent()"
+
+	updateQuery := `UPDATE event_series SET
+		name = $1, description = $2, photo = $3, map_link = $4, category = $5, tags = $7,
+		enriched_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6`
+
+	_, err := r.DB.ExecContext(ctx, updateQuery,
+		series.Name, series.Description, series.Photo, series.MapLink, string(series.Category), series.ID,
+		textArray(series.Tags),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetSeriesEnrichment снимает отметку об обогащении серии, чтобы следующее событие серии
+// было заново обогащено AI (например, после изменения описания на сайте).
+func (r *Repository) ResetSeriesEnrichment(ctx context.Context, id uuid.UUID) error {
+	op := "repository.ResetSeriesEnrichment()"
+
+	updateQuery := `UPDATE event_series SET enriched_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	if _, err := r.DB.ExecContext(ctx, updateQuery, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetEventSeries привязывает событие к серии.
+func (r *Repository) SetEventSeries(ctx context.Context, eventID uuid.UUID, seriesID uuid.UUID) error {
+	op := "repository.SetEventSeries()"
+
+	updateQuery := `UPDATE events SET series_id = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	err := r.withRevision(ctx, eventID, "", func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, updateQuery, seriesID, eventID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FindSeriesEvents возвращает предстоящие и идущие события серии по возрастанию даты.
+// Отклонённые, отменённые события и дубликаты не возвращаются.
+func (r *Repository) FindSeriesEvents(ctx context.Context, seriesID uuid.UUID) ([]domain.Event, error) {
+	op := "repository.FindSeriesEvents()"
+
+	var repoEvents []repositories.Event
+	query := `SELECT ` + eventColumns + `
+	          FROM events
+	          WHERE series_id = $1 AND status NOT IN ($2, $3, $4) AND COALESCE(end_date, date) > CURRENT_TIMESTAMP
+	          ORDER BY date ASC`
+
+	err := r.DB.SelectContext(ctx, &repoEvents, query,
+		seriesID,
+		string(domain.EventStatusRejected),
+		string(domain.EventStatusCancelled),
+		string(domain.EventStatusDuplicate),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.Event, len(repoEvents))
+	for i, e := range repoEvents {
+		result[i] = mapToDomain(e)
+	}
+
+	return result, nil
+}
+
+// FindSeriesPosts возвращает сообщения Telegram, в которых опубликованы события серии.
+func (r *Repository) FindSeriesPosts(ctx context.Context, seriesID uuid.UUID) ([]domain.EventPost, error) {
+	op := "repository.FindSeriesPosts()"
+
+	var repoPosts []repositories.EventPost
+	query := `SELECT p.event_id, p.chat_id, p.message_id, p.is_photo, p.created_at
+	          FROM event_posts p JOIN events e ON e.id = p.event_id
+	          WHERE e.series_id = $1 ORDER BY p.created_at ASC`
+
+	err := r.DB.SelectContext(ctx, &repoPosts, query, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.EventPost, len(repoPosts))
+	for i, p := range repoPosts {
+		result[i] = mapEventPostToDomain(p)
+	}
+
+	return result, nil
+}
+
+func mapSeriesToDomain(s repositories.EventSeries) domain.EventSeries {
+	return domain.EventSeries{
+		ID:          s.ID,
+		SiteName:    s.SiteName,
+		EventLink:   s.EventLink,
+		Name:        s.Name,
+		Description: s.Description,
+		Photo:       s.Photo,
+		MapLink:     s.MapLink,
+		Category:    domain.Category(s.Category),
+		Tags:        []string(s.Tags),
+		EnrichedAt:  s.EnrichedAt.Time,
+	}
+}
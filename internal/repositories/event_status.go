@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/models/repositories"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// recordStatusChange проверяет смену статуса события между состояниями before и after
+// по таблице переходов (domain.CheckTransition) и записывает её в историю статусов.
+// Начальный статус нового события записывается без проверки; удаление события не записывается.
+func recordStatusChange(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID, before, after eventState, reason string) error {
+	if after == nil || after["status"] == nil {
+		return nil
+	}
+
+	to := domain.EventStatus(*after["status"])
+	var from sql.NullString
+	if before != nil && before["status"] != nil {
+		from = sql.NullString{String: *before["status"], Valid: true}
+		if domain.EventStatus(from.String) == to {
+			return nil
+		}
+	}
+
+	actor := domain.ActorFromContext(ctx)
+	if from.Valid {
+		if err := domain.CheckTransition(domain.EventStatus(from.String), to, actor); err != nil {
+			return err
+		}
+	}
+
+	insertQuery := `INSERT INTO event_status_history (id, event_id, from_status, to_status, actor_type, actor_id, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := tx.ExecContext(ctx, insertQuery,
+		uuid.New(),
+		eventID,
+		from,
+		string(to),
+		string(actor.Type),
+		actor.ID,
+		reason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save status change: %w", err)
+	}
+	return nil
+}
+
+// FindStatusHistory возвращает историю статусов события от старых переходов к новым.
+func (r *Repository) FindStatusHistory(ctx context.Context, eventID uuid.UUID) ([]domain.StatusChange, error) {
+	op := "repository.FindStatusHistory()"
+
+	var repoChanges []repositories.StatusChange
+	query := `SELECT id, event_id, from_status, to_status, actor_type, actor_id, reason, created_at
+	          FROM event_status_history WHERE event_id = $1 ORDER BY created_at ASC`
+
+	if err := r.DB.SelectContext(ctx, &repoChanges, query, eventID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.StatusChange, len(repoChanges))
+	for i, c := range repoChanges {
+		result[i] = mapStatusChangeToDomain(c)
+	}
+
+	return result, nil
+}
+
+func mapStatusChangeToDomain(c repositories.StatusChange) domain.StatusChange {
+	return domain.StatusChange{
+		ID:        c.ID,
+		EventID:   c.EventID,
+		From:      domain.EventStatus(c.FromStatus.String),
+		To:        domain.EventStatus(c.ToStatus),
+		Actor:     domain.Actor{Type: domain.ActorType(c.ActorType), ID: c.ActorID},
+		Reason:    c.Reason,
+		CreatedAt: c.CreatedAt,
+	}
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/models/repositories"
+)
+
+// imageColumns — список колонок таблицы images для SELECT.
+const imageColumns = `hash, path, source_url, content_type, size, width, height, telegram_file_id, created_at`
+
+// SaveImage сохраняет сведения о скачанном фото. Фото с тем же хешем уже может быть
+// сохранено (то же фото по другой ссылке) — тогда запись не меняется.
+func (r *Repository) SaveImage(ctx context.Context, image domain.Image) error {
+	op := "repository.SaveImage()"
+
+	query := `INSERT INTO images (hash, path, source_url, content_type, size, width, height, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+		ON CONFLICT (hash) DO NOTHING`
+
+	_, err := r.DB.ExecContext(ctx, query,
+		image.Hash, image.Path, image.SourceURL, image.ContentType, image.Size, image.Width, image.Height,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FindImage возвращает фото по хешу содержимого.
+func (r *Repository) FindImage(ctx context.Context, hash string) (domain.Image, error) {
+	op := "repository.FindImage()"
+
+	var repoImage repositories.Image
+	query := `SELECT ` + imageColumns + ` FROM images WHERE hash = $1`
+
+	err := r.DB.GetContext(ctx, &repoImage, query, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Image{}, fmt.Errorf("%s: image not found with hash %s", op, hash)
+		}
+		return domain.Image{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapImageToDomain(repoImage), nil
+}
+
+// FindImageBySourceURL возвращает фото, скачанное по ссылке sourceURL.
+// Если по этой ссылке фото не скачивалось, возвращает пустое фото без ошибки.
+func (r *Repository) FindImageBySourceURL(ctx context.Context, sourceURL string) (domain.Image, error) {
+	op := "repository.FindImageBySourceURL()"
+
+	var repoImage repositories.Image
+	query := `SELECT ` + imageColumns + ` FROM images WHERE source_url = $1 ORDER BY created_at DESC LIMIT 1`
+
+	err := r.DB.GetContext(ctx, &repoImage, query, sourceURL)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Image{}, nil
+		}
+		return domain.Image{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapImageToDomain(repoImage), nil
+}
+
+// SetImageTelegramFileID запоминает file_id фото, загруженного в Telegram.
+func (r *Repository) SetImageTelegramFileID(ctx context.Context, hash string, fileID string) error {
+	op := "repository.SetImageTelegramFileID()"
+
+	updateQuery := `UPDATE images SET telegram_file_id = $1 WHERE hash = $2`
+
+	if _, err := r.DB.ExecContext(ctx, updateQuery, fileID, hash); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func mapImageToDomain(i repositories.Image) domain.Image {
+	return domain.Image{
+		Hash:           i.Hash,
+		Path:           i.Path,
+		SourceURL:      i.SourceURL,
+		ContentType:    i.ContentType,
+		Size:           i.Size,
+		Width:          i.Width,
+		Height:         i.Height,
+		TelegramFileID: i.TelegramFileID,
+	}
+}
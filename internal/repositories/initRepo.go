@@ -58,10 +58,19 @@ func New(logger *slog.Logger, cfg *config.Config) *Repository {
 		panic("error running database migrations")
 	}
 
-	return &Repository{
+	r := &Repository{
 		DB:  conn,
 		log: log,
 	}
+
+	// перенос строк тегов событий и серий, сохранённых до появления таблицы tags;
+	// без него у старых событий пропали бы теги, поэтому ошибка останавливает запуск, как и ошибка миграций
+	if err := r.migrateLegacyTags(context.Background()); err != nil {
+		log.Error("error migrating legacy tags", sl.Err(err))
+		panic("error migrating legacy tags")
+	}
+
+	return r
 }
 
 func (r *Repository) Shutdown(ctx context.Context) error {
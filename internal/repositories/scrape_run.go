@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/models/repositories"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// scrapeRunColumns — список колонок таблицы scrape_runs для SELECT.
+const scrapeRunColumns = `id, request_id, site_name, status, events_count, incomplete_count,
+	skipped_count, blocked_count, new_count, changed_count, duplicate_count, failed_count,
+	problems, error, started_at, finished_at`
+
+// CreateScrapeRun сохраняет запуск скрапера. Повторная запись с тем же requestID заменяет предыдущую.
+func (r *Repository) CreateScrapeRun(ctx context.Context, run domain.ScrapeRun) error {
+	op := "repository.CreateScrapeRun()"
+
+	problems := run.Problems
+	if problems == nil {
+		problems = []string{}
+	}
+
+	insertQuery := `INSERT INTO scrape_runs (` + scrapeRunColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (request_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			events_count = EXCLUDED.events_count,
+			incomplete_count = EXCLUDED.incomplete_count,
+			skipped_count = EXCLUDED.skipped_count,
+			blocked_count = EXCLUDED.blocked_count,
+			new_count = EXCLUDED.new_count,
+			changed_count = EXCLUDED.changed_count,
+			duplicate_count = EXCLUDED.duplicate_count,
+			failed_count = EXCLUDED.failed_count,
+			problems = EXCLUDED.problems,
+			error = EXCLUDED.error,
+			finished_at = EXCLUDED.finished_at`
+
+	_, err := r.DB.ExecContext(ctx, insertQuery,
+		run.ID, run.RequestID, run.SiteName, string(run.Status),
+		run.EventsCount, run.IncompleteCount, run.SkippedCount, run.BlockedCount,
+		run.NewCount, run.ChangedCount, run.DuplicateCount, run.FailedCount,
+		pq.StringArray(problems), run.Error, run.StartedAt, run.FinishedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FindScrapeRuns возвращает запуски скраперов, подходящие под фильтр, начиная с самого свежего.
+func (r *Repository) FindScrapeRuns(ctx context.Context, filter domain.ScrapeRunFilter) ([]domain.ScrapeRun, error) {
+	op := "repository.FindScrapeRuns()"
+
+	var conditions []string
+	var args []any
+	if filter.SiteName != "" {
+		args = append(args, filter.SiteName)
+		conditions = append(conditions, fmt.Sprintf("site_name = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `SELECT ` + scrapeRunColumns + ` FROM scrape_runs`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY started_at DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	var repoRuns []repositories.ScrapeRun
+	err := r.DB.SelectContext(ctx, &repoRuns, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.ScrapeRun, len(repoRuns))
+	for i, run := range repoRuns {
+		result[i] = mapScrapeRunToDomain(run)
+	}
+
+	return result, nil
+}
+
+// FindScrapeRunByRequestID возвращает запуск скрапера по идентификатору задачи.
+func (r *Repository) FindScrapeRunByRequestID(ctx context.Context, requestID uuid.UUID) (domain.ScrapeRun, error) {
+	op := "repository.FindScrapeRunByRequestID()"
+
+	var repoRun repositories.ScrapeRun
+	query := `SELECT ` + scrapeRunColumns + ` FROM scrape_runs WHERE request_id = $1`
+
+	err := r.DB.GetContext(ctx, &repoRun, query, requestID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ScrapeRun{}, fmt.Errorf("%w with request id: %s", domain.ErrScrapeRunNotFound, requestID)
+		}
+		return domain.ScrapeRun{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapScrapeRunToDomain(repoRun), nil
+}
+
+// mapScrapeRunToDomain преобразует модель БД в доменную модель запуска скрапера.
+func mapScrapeRunToDomain(run repositories.ScrapeRun) domain.ScrapeRun {
+	return domain.ScrapeRun{
+		ID:              run.ID,
+		RequestID:       run.RequestID,
+		SiteName:        run.SiteName,
+		Status:          domain.ScrapeRunStatus(run.Status),
+		EventsCount:     run.EventsCount,
+		IncompleteCount: run.IncompleteCount,
+		SkippedCount:    run.SkippedCount,
+		BlockedCount:    run.BlockedCount,
+		NewCount:        run.NewCount,
+		ChangedCount:    run.ChangedCount,
+		DuplicateCount:  run.DuplicateCount,
+		FailedCount:     run.FailedCount,
+		Problems:        run.Problems,
+		Error:           run.Error,
+		StartedAt:       run.StartedAt,
+		FinishedAt:      run.FinishedAt,
+	}
+}
@@ -0,0 +1,296 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/models/repositories"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// resolveTags нормализует теги и заменяет синонимы и переводы тегами из справочника tag_aliases.
+// Порядок тегов сохраняется, повторы убираются.
+func resolveTags(ctx context.Context, tx *sqlx.Tx, tags []string) ([]string, error) {
+	normalized := domain.NormalizeTags(tags)
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+
+	var resolved []string
+	query := `SELECT COALESCE(a.tag, n.name)
+		FROM unnest($1::text[]) WITH ORDINALITY AS n(name, position)
+		LEFT JOIN tag_aliases a ON a.alias = n.name
+		ORDER BY n.position`
+
+	if err := tx.SelectContext(ctx, &resolved, query, pq.StringArray(normalized)); err != nil {
+		return nil, fmt.Errorf("failed to resolve tag aliases: %w", err)
+	}
+	return domain.NormalizeTags(resolved), nil
+}
+
+// setEventTags заменяет теги события нормализованными тегами tags и возвращает сохранённые теги.
+// Тег с названием категории события не сохраняется: категория и так показывается хэштегом.
+func setEventTags(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID, category domain.Category, tags []string) ([]string, error) {
+	resolved, err := resolveTags(ctx, tx, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM event_tags WHERE event_id = $1`, eventID); err != nil {
+		return nil, fmt.Errorf("failed to delete event tags: %w", err)
+	}
+
+	// DO UPDATE вместо DO NOTHING, чтобы RETURNING вернул и уже существующий тег
+	tagQuery := `INSERT INTO tags (id, name) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id`
+	linkQuery := `INSERT INTO event_tags (event_id, tag_id, position) VALUES ($1, $2, $3)`
+
+	var saved []string
+	for _, name := range resolved {
+		if name == category.Label() || name == string(category) {
+			continue
+		}
+
+		var tagID uuid.UUID
+		if err := tx.GetContext(ctx, &tagID, tagQuery, uuid.New(), name); err != nil {
+			return nil, fmt.Errorf("failed to save tag %s: %w", name, err)
+		}
+		if _, err := tx.ExecContext(ctx, linkQuery, eventID, tagID, len(saved)); err != nil {
+			return nil, fmt.Errorf("failed to save event tag %s: %w", name, err)
+		}
+		saved = append(saved, name)
+	}
+	return saved, nil
+}
+
+// FindTags возвращает теги и число событий с каждым из них, начиная с самых частых.
+func (r *Repository) FindTags(ctx context.Context) ([]domain.Tag, error) {
+	op := "repository.FindTags()"
+
+	var repoTags []repositories.Tag
+	query := `SELECT t.id, t.name, COUNT(et.event_id) AS events_count
+	          FROM tags t LEFT JOIN event_tags et ON et.tag_id = t.id
+	          GROUP BY t.id, t.name
+	          ORDER BY events_count DESC, t.name ASC`
+
+	if err := r.DB.SelectContext(ctx, &repoTags, query); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.Tag, len(repoTags))
+	for i, t := range repoTags {
+		result[i] = domain.Tag{ID: t.ID, Name: t.Name, EventsCount: t.EventsCount}
+	}
+
+	return result, nil
+}
+
+// FindTagAliases возвращает справочник синонимов тегов.
+func (r *Repository) FindTagAliases(ctx context.Context) ([]domain.TagAlias, error) {
+	op := "repository.FindTagAliases()"
+
+	var repoAliases []repositories.TagAlias
+	query := `SELECT alias, tag FROM tag_aliases ORDER BY tag ASC, alias ASC`
+
+	if err := r.DB.SelectContext(ctx, &repoAliases, query); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]domain.TagAlias, len(repoAliases))
+	for i, a := range repoAliases {
+		result[i] = domain.TagAlias{Alias: a.Alias, Tag: a.Tag}
+	}
+
+	return result, nil
+}
+
+// SaveTagAlias добавляет или изменяет синоним тега. Уже сохранённый тег с названием синонима
+// объединяется с тегом alias.Tag: его события получают alias.Tag, а сам тег удаляется.
+// Синонимы, указывавшие на alias.Alias, перенаправляются на alias.Tag. Возвращает сохранённый синоним.
+func (r *Repository) SaveTagAlias(ctx context.Context, alias domain.TagAlias) (domain.TagAlias, error) {
+	op := "repository.SaveTagAlias()"
+
+	alias.Alias, alias.Tag = domain.NormalizeTag(alias.Alias), domain.NormalizeTag(alias.Tag)
+	if alias.Alias == "" || alias.Tag == "" || alias.Alias == alias.Tag {
+		return domain.TagAlias{}, fmt.Errorf("%s: invalid alias %q -> %q", op, alias.Alias, alias.Tag)
+	}
+
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.TagAlias{}, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	// Синоним всегда указывает на тег, а не на другой синоним
+	resolved, err := resolveTags(ctx, tx, []string{alias.Tag})
+	if err != nil {
+		return domain.TagAlias{}, fmt.Errorf("%s: %w", op, err)
+	}
+	alias.Tag = resolved[0]
+	if alias.Alias == alias.Tag {
+		return domain.TagAlias{}, fmt.Errorf("%s: alias %q already resolves to itself", op, alias.Alias)
+	}
+
+	queries := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO tag_aliases (alias, tag) VALUES ($1, $2) ON CONFLICT (alias) DO UPDATE SET tag = EXCLUDED.tag`,
+			[]any{alias.Alias, alias.Tag}},
+		{`UPDATE tag_aliases SET tag = $2 WHERE tag = $1`,
+			[]any{alias.Alias, alias.Tag}},
+		{`INSERT INTO tags (id, name) SELECT $1, $2 WHERE EXISTS (SELECT 1 FROM tags WHERE name = $3)
+			ON CONFLICT (name) DO NOTHING`,
+			[]any{uuid.New(), alias.Tag, alias.Alias}},
+		{`INSERT INTO event_tags (event_id, tag_id, position)
+			SELECT et.event_id, target.id, et.position
+			FROM event_tags et JOIN tags old ON old.id = et.tag_id, tags target
+			WHERE old.name = $1 AND target.name = $2
+			ON CONFLICT (event_id, tag_id) DO NOTHING`,
+			[]any{alias.Alias, alias.Tag}},
+		{`DELETE FROM tags WHERE name = $1`,
+			[]any{alias.Alias}},
+	}
+	for _, q := range queries {
+		if _, err := tx.ExecContext(ctx, q.query, q.args...); err != nil {
+			return domain.TagAlias{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.TagAlias{}, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return alias, nil
+}
+
+// DeleteTagAlias удаляет синоним тега. Теги, уже сохранённые под основным названием, не меняются.
+func (r *Repository) DeleteTagAlias(ctx context.Context, alias string) error {
+	op := "repository.DeleteTagAlias()"
+
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM tag_aliases WHERE alias = $1`, domain.NormalizeTag(alias))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get rows affected: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w: %s", op, domain.ErrTagAliasNotFound, alias)
+	}
+
+	return nil
+}
+
+// legacyTags — строка тегов "#a #b" события или серии, сохранённая до появления тегов.
+type legacyTags struct {
+	ID       uuid.UUID `db:"id"`
+	Tag      string    `db:"tag"`
+	Category string    `db:"category"`
+}
+
+// migrateLegacyTags переносит строки тегов "#a #b" из events.tag и event_series.tag в теги
+// и категорию событий и серий (см. миграцию 018_add_tags.sql). Теги нормализуются и заменяются
+// синонимами так же, как при сохранении события. Перенесённая строка очищается, поэтому повторный
+// запуск обрабатывает только события и серии, перенос которых не удался.
+func (r *Repository) migrateLegacyTags(ctx context.Context) error {
+	op := "repository.migrateLegacyTags()"
+
+	var legacy []legacyTags
+	if err := r.DB.SelectContext(ctx, &legacy, `SELECT id, tag, category FROM events WHERE tag <> ''`); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, event := range legacy {
+		if err := r.migrateEventTags(ctx, event.ID, event.Tag, domain.Category(event.Category)); err != nil {
+			return fmt.Errorf("%s: event %s: %w", op, event.ID, err)
+		}
+	}
+
+	if len(legacy) > 0 {
+		r.log.Info("legacy event tags migrated", slog.Int("events", len(legacy)))
+	}
+
+	var legacySeries []legacyTags
+	if err := r.DB.SelectContext(ctx, &legacySeries, `SELECT id, tag, category FROM event_series WHERE tag <> ''`); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, series := range legacySeries {
+		if err := r.migrateSeriesTags(ctx, series.ID, series.Tag, domain.Category(series.Category)); err != nil {
+			return fmt.Errorf("%s: series %s: %w", op, series.ID, err)
+		}
+	}
+
+	if len(legacySeries) > 0 {
+		r.log.Info("legacy series tags migrated", slog.Int("series", len(legacySeries)))
+	}
+	return nil
+}
+
+// migrateEventTags переносит строку тегов одного события. Категория определяется по тегам,
+// если у события её ещё нет.
+func (r *Repository) migrateEventTags(ctx context.Context, eventID uuid.UUID, legacyTag string, category domain.Category) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tags, err := resolveTags(ctx, tx, domain.ParseHashtags(legacyTag))
+	if err != nil {
+		return err
+	}
+	if category == "" {
+		category, _ = domain.CategoryFromTags(tags)
+	}
+
+	if _, err := setEventTags(ctx, tx, eventID, category, tags); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE events SET category = $1, tag = '' WHERE id = $2`, string(category), eventID); err != nil {
+		return fmt.Errorf("failed to clear legacy tag: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// migrateSeriesTags переносит строку тегов одной серии. Категория определяется по тегам,
+// если у серии её ещё нет.
+func (r *Repository) migrateSeriesTags(ctx context.Context, seriesID uuid.UUID, legacyTag string, category domain.Category) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tags, err := resolveTags(ctx, tx, domain.ParseHashtags(legacyTag))
+	if err != nil {
+		return err
+	}
+	if category == "" {
+		category, _ = domain.CategoryFromTags(tags)
+	}
+
+	updateQuery := `UPDATE event_series SET category = $1, tags = $2, tag = '' WHERE id = $3`
+	if _, err := tx.ExecContext(ctx, updateQuery, string(category), textArray(tags), seriesID); err != nil {
+		return fmt.Errorf("failed to save series tags: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
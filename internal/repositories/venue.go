@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/models/repositories"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// venueColumns — список колонок таблицы venues для SELECT.
+const venueColumns = `id, key, name, address, latitude, longitude, site_name, created_at, updated_at`
+
+// FindOrCreateVenue возвращает сохранённое место с тем же ключом, что и venue, а если его нет —
+// сохраняет venue как новое. У найденного места заполняется пустой адрес, а координаты
+// обновляются, если они известны venue (например, место добавлено в справочник).
+func (r *Repository) FindOrCreateVenue(ctx context.Context, venue domain.Venue) (domain.Venue, error) {
+	op := "repository.FindOrCreateVenue()"
+
+	if venue.ID == uuid.Nil {
+		venue.ID = uuid.New()
+	}
+
+	repoVenue := mapVenueToRepo(venue)
+
+	query := `INSERT INTO venues (id, key, name, address, latitude, longitude, site_name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (key) DO UPDATE SET
+			address = CASE WHEN venues.address = '' THEN EXCLUDED.address ELSE venues.address END,
+			latitude = COALESCE(EXCLUDED.latitude, venues.latitude),
+			longitude = COALESCE(EXCLUDED.longitude, venues.longitude),
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING ` + venueColumns
+
+	err := r.DB.GetContext(ctx, &repoVenue, query,
+		repoVenue.ID, repoVenue.Key, repoVenue.Name, repoVenue.Address,
+		repoVenue.Latitude, repoVenue.Longitude, repoVenue.SiteName,
+	)
+	if err != nil {
+		return domain.Venue{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mapVenueToDomain(repoVenue), nil
+}
+
+// SetEventVenue привязывает событие к месту проведения и сохраняет построенную по нему ссылку на карту.
+func (r *Repository) SetEventVenue(ctx context.Context, eventID uuid.UUID, venueID uuid.UUID, mapLink string) error {
+	op := "repository.SetEventVenue()"
+
+	updateQuery := `UPDATE events SET venue_id = $1, map_link = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3`
+
+	err := r.withRevision(ctx, eventID, "", func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, updateQuery, venueID, mapLink, eventID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func mapVenueToRepo(v domain.Venue) repositories.Venue {
+	return repositories.Venue{
+		BaseModel: repositories.BaseModel{
+			ID: v.ID,
+		},
+		Key:       v.Key(),
+		Name:      v.Name,
+		Address:   v.Address,
+		Latitude:  sql.NullFloat64{Float64: v.Latitude, Valid: v.HasCoordinates()},
+		Longitude: sql.NullFloat64{Float64: v.Longitude, Valid: v.HasCoordinates()},
+		SiteName:  v.SiteName,
+	}
+}
+
+func mapVenueToDomain(v repositories.Venue) domain.Venue {
+	return domain.Venue{
+		ID:        v.ID,
+		Name:      v.Name,
+		Address:   v.Address,
+		Latitude:  v.Latitude.Float64,
+		Longitude: v.Longitude.Float64,
+		SiteName:  v.SiteName,
+	}
+}
@@ -0,0 +1,259 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule вычисляет время следующего запуска.
+type Schedule interface {
+	// Next возвращает первый момент запуска строго после t.
+	Next(t time.Time) time.Time
+}
+
+// everySchedule — запуск с фиксированным интервалом ("@every 30m").
+type everySchedule struct {
+	interval time.Duration
+}
+
+// Next возвращает t + интервал.
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// cronSchedule — расписание в формате cron из пяти полей:
+// минута, час, день месяца, месяц, день недели.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64 // Битовые маски допустимых значений
+	domAny, dowAny                bool   // Поле задано как "*"
+	hourAny                       bool   // Подходит любой час
+	location                      *time.Location
+}
+
+// cronField описывает диапазон значений поля cron.
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = cronField{min: 0, max: 59}
+	hourField   = cronField{min: 0, max: 23}
+	domField    = cronField{min: 1, max: 31}
+	monthField  = cronField{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = cronField{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronDescriptors — сокращённые записи распространённых расписаний.
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse разбирает расписание: выражение cron из пяти полей ("*/30 8-22 * * mon-fri"),
+// сокращение ("@daily", "@hourly", ...) или интервал ("@every 2h").
+// Выражения cron вычисляются в часовом поясе loc (по умолчанию UTC).
+func Parse(spec string, loc *time.Location) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if after, ok := strings.CutPrefix(spec, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(after))
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval %q: %w", after, err)
+		}
+		if interval < time.Minute {
+			return nil, fmt.Errorf("interval %s is less than a minute", interval)
+		}
+		return everySchedule{interval: interval}, nil
+	}
+
+	if expanded, ok := cronDescriptors[strings.ToLower(spec)]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	s := &cronSchedule{location: loc}
+	var err error
+
+	if s.minute, err = parseCronField(fields[0], minuteField); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if s.hour, err = parseCronField(fields[1], hourField); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if s.dom, err = parseCronField(fields[2], domField); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if s.month, err = parseCronField(fields[3], monthField); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if s.dow, err = parseCronField(fields[4], dowField); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	// Воскресенье можно указать как 0 или 7
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domAny = fields[2] == "*"
+	s.dowAny = fields[4] == "*"
+	s.hourAny = s.hour == 1<<24-1
+
+	return s, nil
+}
+
+// parseCronField разбирает поле cron: список через запятую из "*", значений,
+// диапазонов "a-b" и шагов "*/n" или "a-b/n".
+func parseCronField(expr string, field cronField) (uint64, error) {
+	var mask uint64
+
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepExpr)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepExpr)
+			}
+		}
+
+		var from, to int
+		switch {
+		case rangeExpr == "*":
+			from, to = field.min, field.max
+		case strings.Contains(rangeExpr, "-"):
+			lo, hi, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if from, err = field.value(lo); err != nil {
+				return 0, err
+			}
+			if to, err = field.value(hi); err != nil {
+				return 0, err
+			}
+		default:
+			value, err := field.value(rangeExpr)
+			if err != nil {
+				return 0, err
+			}
+			from, to = value, value
+			// "5/15" означает "с 5 до конца диапазона с шагом 15"
+			if hasStep {
+				to = field.max
+			}
+		}
+
+		if from > to {
+			return 0, fmt.Errorf("invalid range %q", rangeExpr)
+		}
+		for v := from; v <= to; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+
+	return mask, nil
+}
+
+// value разбирает одно значение поля (число или название).
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next возвращает первый момент после t, подходящий под все поля выражения.
+// Перебор идёт по минутам с пропуском неподходящих месяцев, дней и часов;
+// если за пять лет подходящего момента нет (например, "30 февраля"), возвращается нулевое время.
+//
+// При переходе на летнее время пропущенное время не наступает, и запуск на него переносится
+// на следующий подходящий момент. При переходе на зимнее время повторяющийся час
+// для расписаний с конкретными часами выполняется один раз; расписания на каждый час идут по реальному времени.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = firstOccurrence(time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location))
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = firstOccurrence(time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location))
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = firstOccurrence(time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location))
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		if !s.hourAny && firstOccurrence(t).Before(t) {
+			// Это время уже было до перевода часов назад
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// firstOccurrence возвращает первый момент с тем же местным временем, что и t.
+// Отличается от t только в часе, который повторяется при переводе часов назад.
+func firstOccurrence(t time.Time) time.Time {
+	_, offset := t.Zone()
+	_, prevOffset := t.Add(-12 * time.Hour).Zone()
+	if shift := time.Duration(prevOffset-offset) * time.Second; shift > 0 {
+		if earlier := t.Add(-shift); earlier.Hour() == t.Hour() && earlier.Minute() == t.Minute() {
+			return earlier
+		}
+	}
+	return t
+}
+
+// dayMatches проверяет день месяца и день недели. Как и в классическом cron, если оба поля
+// ограничены, достаточно совпадения любого из них.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+// bits возвращает битовую маску значений поля cron.
+func bits(values ...int) uint64 {
+	var mask uint64
+	for _, v := range values {
+		mask |= 1 << uint(v)
+	}
+	return mask
+}
+
+// span возвращает битовую маску значений от from до to с шагом step.
+func span(from, to, step int) uint64 {
+	var mask uint64
+	for v := from; v <= to; v += step {
+		mask |= 1 << uint(v)
+	}
+	return mask
+}
+
+func TestParseCronField(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		field   cronField
+		want    uint64
+		wantErr bool
+	}{
+		{"any minute", "*", minuteField, span(0, 59, 1), false},
+		{"single value", "5", minuteField, bits(5), false},
+		{"range", "8-22", hourField, span(8, 22, 1), false},
+		{"step over any", "*/15", minuteField, bits(0, 15, 30, 45), false},
+		{"step over range", "10-30/10", minuteField, bits(10, 20, 30), false},
+		{"step from value", "5/20", minuteField, bits(5, 25, 45), false},
+		{"list", "1,15,31", domField, bits(1, 15, 31), false},
+		{"list of ranges and steps", "1-3,10,*/20", minuteField, bits(0, 1, 2, 3, 10, 20, 40), false},
+		{"month names", "jan-mar,DEC", monthField, bits(1, 2, 3, 12), false},
+		{"weekday names", "mon-fri", dowField, span(1, 5, 1), false},
+		{"sunday as seven", "7", dowField, bits(7), false},
+		{"value below range", "0", domField, 0, true},
+		{"value above range", "60", minuteField, 0, true},
+		{"reversed range", "22-8", hourField, 0, true},
+		{"zero step", "*/0", minuteField, 0, true},
+		{"negative step", "*/-5", minuteField, 0, true},
+		{"not a number", "x", hourField, 0, true},
+		{"unknown name", "foo", monthField, 0, true},
+		{"empty list item", "1,", minuteField, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCronField(tt.expr, tt.field)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseCronField(%q) = %b, want error", tt.expr, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCronField(%q) error: %v", tt.expr, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseCronField(%q) = %b, want %b", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"empty", ""},
+		{"too few fields", "0 8 * *"},
+		{"too many fields", "0 0 8 * * *"},
+		{"invalid minute", "60 * * * *"},
+		{"invalid hour", "0 24 * * *"},
+		{"invalid day of month", "0 0 32 * *"},
+		{"invalid month", "0 0 * 13 *"},
+		{"invalid day of week", "0 0 * * 8"},
+		{"unknown descriptor", "@fortnightly"},
+		{"invalid interval", "@every soon"},
+		{"interval below a minute", "@every 30s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.spec, nil); err == nil {
+				t.Errorf("Parse(%q) = nil error, want error", tt.spec)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata is not available: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		spec string
+		loc  *time.Location
+		from time.Time
+		want time.Time
+	}{
+		{"every interval", "@every 90m", time.UTC,
+			time.Date(2026, 1, 1, 10, 15, 30, 0, time.UTC), time.Date(2026, 1, 1, 11, 45, 30, 0, time.UTC)},
+		{"strictly after from", "0 * * * *", time.UTC,
+			time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{"seconds are truncated", "*/30 * * * *", time.UTC,
+			time.Date(2026, 1, 1, 10, 29, 59, 0, time.UTC), time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)},
+		{"next day", "30 8 * * *", time.UTC,
+			time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(2026, 1, 2, 8, 30, 0, 0, time.UTC)},
+		{"month boundary", "0 9 1 * *", time.UTC,
+			time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"skips months without the day", "0 0 31 * *", time.UTC,
+			time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"year boundary", "@yearly", time.UTC,
+			time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.UTC,
+			time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"weekdays", "0 10 * * mon-fri", time.UTC,
+			time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)},
+		{"sunday as seven", "0 10 * * 7", time.UTC,
+			time.Date(2026, 10, 16, 11, 0, 0, 0, time.UTC), time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)},
+		{"day of month or day of week", "0 0 20 * mon", time.UTC,
+			time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{"evaluated in location", "0 9 * * *", berlin,
+			time.Date(2026, 7, 1, 6, 0, 0, 0, time.UTC), time.Date(2026, 7, 1, 9, 0, 0, 0, berlin)},
+		{"hour skipped by spring forward", "30 2 * * *", berlin,
+			time.Date(2026, 3, 29, 0, 0, 0, 0, berlin), time.Date(2026, 3, 30, 2, 30, 0, 0, berlin)},
+		{"hourly across spring forward", "0 * * * *", berlin,
+			time.Date(2026, 3, 29, 1, 30, 0, 0, berlin), time.Date(2026, 3, 29, 3, 0, 0, 0, berlin)},
+		{"fixed time before fall back", "30 2 * * *", berlin,
+			time.Date(2026, 10, 25, 0, 0, 0, 0, berlin), time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC)},
+		{"fixed time runs once on fall back", "30 2 * * *", berlin,
+			time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC), time.Date(2026, 10, 26, 2, 30, 0, 0, berlin)},
+		{"hourly through repeated hour", "0 * * * *", berlin,
+			time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC), time.Date(2026, 10, 25, 1, 0, 0, 0, time.UTC)},
+		{"impossible date", "0 0 30 2 *", time.UTC,
+			time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.spec, tt.loc)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.spec, err)
+			}
+			got := schedule.Next(tt.from)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RunFunc запускает задачу и возвращает канал, который закрывается по её завершении.
+type RunFunc func(name string) (<-chan struct{}, error)
+
+// EntryStatus — состояние задачи планировщика.
+type EntryStatus struct {
+	Name      string
+	Spec      string    // Расписание в исходном виде
+	NextRun   time.Time // Время следующего запуска (с учётом разброса)
+	LastRun   time.Time // Время последнего запуска
+	LastError string    // Ошибка последнего запуска
+	Running   bool      // Предыдущий запуск ещё выполняется
+	Skipped   int       // Число пропущенных запусков из-за незавершённого предыдущего
+}
+
+// entry — задача планировщика.
+type entry struct {
+	name     string
+	spec     string
+	schedule Schedule
+	jitter   time.Duration
+
+	mu      sync.Mutex
+	status  EntryStatus
+	running bool
+}
+
+// Scheduler периодически запускает задачи по расписанию.
+// Запуск пропускается, если предыдущий запуск той же задачи ещё не завершён.
+type Scheduler struct {
+	logger  *slog.Logger
+	run     RunFunc
+	entries map[string]*entry
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// New создаёт планировщик, запускающий задачи через run.
+func New(logger *slog.Logger, run RunFunc) *Scheduler {
+	return &Scheduler{
+		logger:  logger,
+		run:     run,
+		entries: make(map[string]*entry),
+		stop:    make(chan struct{}),
+	}
+}
+
+// Add регистрирует задачу name с расписанием spec (см. Parse) и случайным
+// разбросом времени запуска до jitter.
+func (s *Scheduler) Add(name string, spec string, jitter time.Duration, loc *time.Location) error {
+	if _, exists := s.entries[name]; exists {
+		return fmt.Errorf("entry %s already exists", name)
+	}
+
+	schedule, err := Parse(spec, loc)
+	if err != nil {
+		return fmt.Errorf("invalid schedule for %s: %w", name, err)
+	}
+
+	s.entries[name] = &entry{
+		name:     name,
+		spec:     spec,
+		schedule: schedule,
+		jitter:   jitter,
+		status:   EntryStatus{Name: name, Spec: spec},
+	}
+	return nil
+}
+
+// Start запускает все задачи. Если runOnStart, каждая задача сразу выполняется один раз.
+func (s *Scheduler) Start(runOnStart bool) {
+	op := "Scheduler.Start()"
+	log := s.logger.With(slog.String("op", op))
+
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go s.loop(e, runOnStart)
+	}
+
+	log.Info("scheduler started", slog.Int("entries", len(s.entries)))
+}
+
+// Stop останавливает планировщик и ждёт завершения циклов задач.
+// Уже запущенные задачи не прерываются.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.once.Do(func() { close(s.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("force exit scheduler: %w", ctx.Err())
+	}
+}
+
+// Entries возвращает состояние всех задач, отсортированное по имени.
+func (s *Scheduler) Entries() []EntryStatus {
+	result := make([]EntryStatus, 0, len(s.entries))
+	for _, e := range s.entries {
+		e.mu.Lock()
+		status := e.status
+		status.Running = e.running
+		e.mu.Unlock()
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// loop ждёт наступления времени запуска задачи и запускает её до остановки планировщика.
+func (s *Scheduler) loop(e *entry, runOnStart bool) {
+	defer s.wg.Done()
+	log := s.logger.With(slog.String("op", "Scheduler.loop()"), slog.String("entry", e.name))
+
+	if runOnStart {
+		s.trigger(log, e)
+	}
+
+	for {
+		next := e.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Error("schedule has no next run, stopping entry", slog.String("spec", e.spec))
+			return
+		}
+		if e.jitter > 0 {
+			next = next.Add(rand.N(e.jitter))
+		}
+
+		e.mu.Lock()
+		e.status.NextRun = next
+		e.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.trigger(log, e)
+		}
+	}
+}
+
+// trigger запускает задачу, если предыдущий запуск завершён.
+func (s *Scheduler) trigger(log *slog.Logger, e *entry) {
+	e.mu.Lock()
+	if e.running {
+		e.status.Skipped++
+		e.mu.Unlock()
+		log.Warn("previous run is still in progress, skipping")
+		return
+	}
+	e.running = true
+	e.status.LastRun = time.Now()
+	e.mu.Unlock()
+
+	done, err := s.run(e.name)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err != nil {
+		e.running = false
+		e.status.LastError = err.Error()
+		log.Error("failed to run scheduled job", slog.String("error", err.Error()))
+		return
+	}
+	e.status.LastError = ""
+
+	go func() {
+		select {
+		case <-done:
+		case <-s.stop:
+			// Задачу мог не дождаться завершившийся сервис — освобождаем слот при остановке
+		}
+		e.mu.Lock()
+		e.running = false
+		e.mu.Unlock()
+	}()
+
+	log.Debug("scheduled job started")
+}
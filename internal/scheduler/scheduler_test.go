@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestTriggerSkipsRunningEntry(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	runs := 0
+	done := make(chan struct{})
+	s := New(log, func(string) (<-chan struct{}, error) {
+		runs++
+		return done, nil
+	})
+	if err := s.Add("lococlub", "@hourly", 0, nil); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	e := s.entries["lococlub"]
+
+	s.trigger(log, e)
+	s.trigger(log, e)
+
+	if runs != 1 {
+		t.Errorf("runs = %d, want 1", runs)
+	}
+	if status := s.Entries()[0]; !status.Running || status.Skipped != 1 {
+		t.Errorf("status = %+v, want running with 1 skipped run", status)
+	}
+
+	// Слот освобождается горутиной, ждущей завершения запуска
+	close(done)
+	deadline := time.Now().Add(time.Second)
+	for s.Entries()[0].Running {
+		if time.Now().After(deadline) {
+			t.Fatal("entry is still running after its run completed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	s.trigger(log, e)
+	if runs != 2 {
+		t.Errorf("runs after completion = %d, want 2", runs)
+	}
+	close(s.stop)
+}
@@ -0,0 +1,274 @@
+// Package dedup ищет дубликаты событий: одно и то же мероприятие, найденное на разных сайтах
+// (например, на сайте площадки и у агрегатора билетов) под разными ссылками.
+// Сходство оценивается по нормализованному названию, месту проведения, времени начала и цене.
+package dedup
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+// Значения по умолчанию для настроек поиска дубликатов (config.DedupConfig).
+const (
+	defaultThreshold       = 0.75
+	defaultSameVenueWindow = 12 * time.Hour
+	defaultMaxTimeDiff     = 2 * time.Hour
+)
+
+// Поправки к сходству названий за совпадение или расхождение остальных признаков.
+const (
+	minTitleSimilarity = 0.5 // Ниже этого сходства названий события не считаются дубликатами при любых поправках
+	sameVenueBonus     = 0.15
+	sameTimeBonus      = 0.1
+	samePriceBonus     = 0.05
+	otherPricePenalty  = 0.3
+	sameTimeTolerance  = 15 * time.Minute
+	priceTolerance     = 0.2 // Допустимое расхождение цен: агрегаторы добавляют к цене сборы
+	minContainedTokens = 2   // Сколько значимых слов должно быть в коротком названии, чтобы сравнивать вхождением
+)
+
+// stopWords — служебные слова, которые не учитываются при сравнении названий.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "at": true, "in": true, "of": true, "the": true, "live": true,
+	"de": true, "del": true, "el": true, "la": true, "las": true, "los": true, "en": true, "y": true, "con": true,
+	"i": true, "l": true, "d": true, "amb": true, "els": true,
+	"и": true, "в": true, "на": true, "с": true,
+}
+
+// Matcher оценивает, описывают ли два события одно и то же мероприятие.
+type Matcher struct {
+	threshold       float64
+	sameVenueWindow time.Duration
+	maxTimeDiff     time.Duration
+}
+
+// New создаёт Matcher по настройкам cfg; нулевые значения заменяются значениями по умолчанию.
+func New(cfg config.DedupConfig) *Matcher {
+	m := &Matcher{
+		threshold:       cfg.Threshold,
+		sameVenueWindow: cfg.SameVenueWindow,
+		maxTimeDiff:     cfg.MaxTimeDiff,
+	}
+	if m.threshold == 0 {
+		m.threshold = defaultThreshold
+	}
+	if m.sameVenueWindow <= 0 {
+		m.sameVenueWindow = defaultSameVenueWindow
+	}
+	if m.maxTimeDiff <= 0 {
+		m.maxTimeDiff = defaultMaxTimeDiff
+	}
+	m.sameVenueWindow = max(m.sameVenueWindow, m.maxTimeDiff)
+	return m
+}
+
+// Enabled сообщает, включён ли поиск дубликатов.
+func (m *Matcher) Enabled() bool {
+	return m.threshold > 0
+}
+
+// Window возвращает наибольшую разницу во времени начала, при которой события ещё могут быть
+// дубликатами: кандидатов достаточно искать в пределах ±Window от даты события.
+func (m *Matcher) Window() time.Duration {
+	return m.sameVenueWindow
+}
+
+// Best возвращает кандидата, дубликатом которого является event, и оценку сходства с ним.
+// Второе значение false, если ни один кандидат не набрал порога.
+func (m *Matcher) Best(event domain.Event, candidates []domain.Event) (domain.Event, float64, bool) {
+	var best domain.Event
+	var bestScore float64
+	for _, candidate := range candidates {
+		if score := m.Score(event, candidate); score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	if !m.Enabled() || bestScore < m.threshold {
+		return domain.Event{}, 0, false
+	}
+	return best, bestScore, true
+}
+
+// Score оценивает сходство событий от 0 до 1. Основа оценки — сходство названий; совпадение места,
+// времени и цены её повышает, расхождение цен — понижает. События без даты, с одной ссылкой,
+// в разных местах, слишком далёкие по времени или с непохожими названиями не сравниваются (оценка 0).
+func (m *Matcher) Score(a, b domain.Event) float64 {
+	if a.Date.IsZero() || b.Date.IsZero() || a.EventLink == b.EventLink {
+		return 0
+	}
+
+	// В одном месте события сравниваются в пределах sameVenueWindow, если место хотя бы
+	// одного события неизвестно — в пределах maxTimeDiff
+	diff := a.Date.Sub(b.Date).Abs()
+	venue := compareVenues(a, b)
+	if venue < 0 || diff > m.sameVenueWindow || (venue == 0 && diff > m.maxTimeDiff) {
+		return 0
+	}
+
+	score := titleSimilarity(a, b)
+	if score < minTitleSimilarity {
+		return 0
+	}
+	if venue > 0 {
+		score += sameVenueBonus
+	}
+	if diff <= sameTimeTolerance {
+		score += sameTimeBonus
+	}
+	switch comparePrices(a.Price, b.Price) {
+	case 1:
+		score += samePriceBonus
+	case -1:
+		score -= otherPricePenalty
+	}
+
+	return math.Max(0, math.Min(1, score))
+}
+
+// compareVenues возвращает 1, если у событий одно место проведения, -1, если места разные,
+// и 0, если место хотя бы одного события неизвестно.
+func compareVenues(a, b domain.Event) int {
+	if a.VenueID != uuid.Nil && b.VenueID != uuid.Nil {
+		if a.VenueID == b.VenueID {
+			return 1
+		}
+		return -1
+	}
+	keyA, keyB := a.Venue.Key(), b.Venue.Key()
+	if keyA == "" || keyB == "" {
+		return 0
+	}
+	if keyA == keyB {
+		return 1
+	}
+	return -1
+}
+
+// comparePrices возвращает 1, если цены совпадают с учётом сборов агрегаторов, -1, если цены
+// явно разные (например, бесплатно и платно), и 0, если цена хотя бы одного события неизвестна
+// или указана в разных валютах.
+func comparePrices(a, b domain.Price) int {
+	knownA, knownB := a.Free || a.Min > 0, b.Free || b.Min > 0
+	if !knownA || !knownB {
+		return 0
+	}
+	if a.Free || b.Free {
+		if a.Free == b.Free {
+			return 1
+		}
+		return -1
+	}
+	if a.Currency != "" && b.Currency != "" && a.Currency != b.Currency {
+		return 0
+	}
+
+	// Диапазоны цен пересекаются с учётом допустимого расхождения
+	maxA, maxB := math.Max(a.Min, a.Max), math.Max(b.Min, b.Max)
+	if a.Min <= maxB*(1+priceTolerance) && b.Min <= maxA*(1+priceTolerance) {
+		return 1
+	}
+	return -1
+}
+
+// titleSimilarity возвращает наибольшее сходство названий событий. У сохранённого события
+// сравнивается и название из источника: AI мог переписать название при обогащении.
+func titleSimilarity(a, b domain.Event) float64 {
+	var best float64
+	for _, nameA := range titles(a) {
+		for _, nameB := range titles(b) {
+			best = math.Max(best, similarity(nameA, nameB))
+		}
+	}
+	return best
+}
+
+// titles возвращает нормализованное название события и, если оно отличается, название из источника.
+func titles(e domain.Event) []string {
+	result := []string{domain.NormalizeText(e.Name)}
+	if source := domain.NormalizeText(e.Source[domain.SourceFieldName]); source != "" && source != result[0] {
+		result = append(result, source)
+	}
+	return result
+}
+
+// similarity сравнивает нормализованные названия: по совпадающим словам, по вхождению слов
+// короткого названия в длинное («Rosalía en concierto» и «Rosalía: concierto — Motomami Tour»)
+// и по общим триграммам букв (опечатки и разное написание). Возвращает наибольшую из оценок.
+func similarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	tokensA, tokensB := tokens(a), tokens(b)
+	common := 0
+	for token := range tokensA {
+		if tokensB[token] {
+			common++
+		}
+	}
+
+	var score float64
+	if len(tokensA)+len(tokensB) > 0 {
+		score = 2 * float64(common) / float64(len(tokensA)+len(tokensB))
+	}
+	if shorter := min(len(tokensA), len(tokensB)); shorter >= minContainedTokens && common == shorter {
+		score = 1
+	}
+	return math.Max(score, dice(trigrams(a), trigrams(b)))
+}
+
+// tokens возвращает множество значимых слов названия.
+func tokens(text string) map[string]bool {
+	result := make(map[string]bool)
+	for _, word := range strings.Fields(text) {
+		if !stopWords[word] {
+			result[word] = true
+		}
+	}
+	return result
+}
+
+// trigrams возвращает триграммы букв значимых слов названия, склеенных без пробелов.
+func trigrams(text string) map[string]int {
+	var sb strings.Builder
+	for _, word := range strings.Fields(text) {
+		if !stopWords[word] {
+			sb.WriteString(word)
+		}
+	}
+	runes := []rune(sb.String())
+	result := make(map[string]int)
+	for i := 0; i+3 <= len(runes); i++ {
+		result[string(runes[i:i+3])]++
+	}
+	return result
+}
+
+// dice возвращает коэффициент Сёренсена — Дайса для мультимножеств триграмм.
+func dice(a, b map[string]int) float64 {
+	total := 0
+	for _, n := range a {
+		total += n
+	}
+	for _, n := range b {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+
+	common := 0
+	for gram, n := range a {
+		common += min(n, b[gram])
+	}
+	return 2 * float64(common) / float64(total)
+}
@@ -0,0 +1,144 @@
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestScore(t *testing.T) {
+	start := time.Date(2025, 5, 17, 21, 0, 0, 0, time.UTC)
+	venueID := uuid.New()
+
+	event := func(link, name string, date time.Time, venue string, price domain.Price) domain.Event {
+		return domain.Event{EventLink: link, Name: name, Date: date, Venue: domain.Venue{Name: venue}, Price: price}
+	}
+	eur := func(min, max float64) domain.Price {
+		return domain.Price{Min: min, Max: max, Currency: "EUR"}
+	}
+
+	tests := []struct {
+		name      string
+		a, b      domain.Event
+		duplicate bool
+	}{
+		{
+			name:      "same concert on venue site and ticket aggregator",
+			a:         event("https://sala.example/rosalia", "Rosalía en concierto", start, "Sala Apolo", eur(30, 0)),
+			b:         event("https://tickets.example/e/991", "Rosalía: concierto — Motomami Tour", start, "Sala Apolo", eur(33, 0)),
+			duplicate: true,
+		},
+		{
+			name:      "typo in title",
+			a:         event("https://a.example/1", "Noche de Flamenco", start, "Tablao Cardenal", domain.Price{}),
+			b:         event("https://b.example/2", "Noche de Flamneco", start.Add(10*time.Minute), "Tablao Cardenal", domain.Price{}),
+			duplicate: true,
+		},
+		{
+			name: "same venue id, door time differs",
+			a: func() domain.Event {
+				e := event("https://a.example/jazz", "Jazz Jam Session", start, "", domain.Price{Free: true})
+				e.VenueID = venueID
+				return e
+			}(),
+			b: func() domain.Event {
+				e := event("https://b.example/jazz", "Jazz Jam Session", start.Add(-time.Hour), "", domain.Price{Free: true})
+				e.VenueID = venueID
+				return e
+			}(),
+			duplicate: true,
+		},
+		{
+			name: "enriched title matched by source name",
+			a: func() domain.Event {
+				e := event("https://a.example/cine", "Кино под открытым небом: «Амели»", start, "Jardines del Turia", domain.Price{})
+				e.Source = domain.SourceSnapshot{domain.SourceFieldName: "Cine de verano: Amélie"}
+				return e
+			}(),
+			b:         event("https://b.example/cine", "Cine de verano - Amélie", start, "Jardines del Turia", domain.Price{}),
+			duplicate: true,
+		},
+		{
+			name:      "same link is the same event, not a duplicate",
+			a:         event("https://a.example/1", "Open Mic", start, "Bar", domain.Price{}),
+			b:         event("https://a.example/1", "Open Mic", start, "Bar", domain.Price{}),
+			duplicate: false,
+		},
+		{
+			name:      "different venues",
+			a:         event("https://a.example/1", "Open Mic Night", start, "Bar Central", domain.Price{}),
+			b:         event("https://b.example/1", "Open Mic Night", start, "Café Berlín", domain.Price{}),
+			duplicate: false,
+		},
+		{
+			name:      "next week's date of a weekly event",
+			a:         event("https://a.example/1", "Salsa Social", start, "Sala Son", domain.Price{}),
+			b:         event("https://b.example/1", "Salsa Social", start.AddDate(0, 0, 7), "Sala Son", domain.Price{}),
+			duplicate: false,
+		},
+		{
+			name:      "unknown venue and several hours apart",
+			a:         event("https://a.example/1", "Stand-up comedy", start, "", domain.Price{}),
+			b:         event("https://b.example/1", "Stand-up comedy", start.Add(-4*time.Hour), "", domain.Price{}),
+			duplicate: false,
+		},
+		{
+			name:      "different shows at the same venue and time",
+			a:         event("https://a.example/1", "Hamlet", start, "Teatro Principal", eur(20, 0)),
+			b:         event("https://b.example/1", "La Traviata", start, "Teatro Principal", eur(20, 0)),
+			duplicate: false,
+		},
+		{
+			name:      "free and paid events with the same artist",
+			a:         event("https://a.example/1", "Marina Herlop", start, "", domain.Price{Free: true}),
+			b:         event("https://b.example/1", "Marina Herlop concierto", start.Add(30*time.Minute), "", eur(18, 0)),
+			duplicate: false,
+		},
+		{
+			name:      "no date",
+			a:         event("https://a.example/1", "Mercado de artesanía", time.Time{}, "Plaza", domain.Price{}),
+			b:         event("https://b.example/1", "Mercado de artesanía", start, "Plaza", domain.Price{}),
+			duplicate: false,
+		},
+	}
+
+	m := New(config.DedupConfig{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := m.Score(tt.a, tt.b)
+			if score < 0 || score > 1 {
+				t.Fatalf("Score() = %v, want value in [0, 1]", score)
+			}
+			if (score >= defaultThreshold) != tt.duplicate {
+				t.Errorf("Score() = %.2f, duplicate = %v; want duplicate = %v", score, score >= defaultThreshold, tt.duplicate)
+			}
+			if reverse := m.Score(tt.b, tt.a); reverse != score {
+				t.Errorf("Score() is not symmetric: %v and %v", score, reverse)
+			}
+		})
+	}
+}
+
+func TestBest(t *testing.T) {
+	start := time.Date(2025, 5, 17, 21, 0, 0, 0, time.UTC)
+	event := domain.Event{EventLink: "https://tickets.example/1", Name: "Vetusta Morla", Date: start, Venue: domain.Venue{Name: "WiZink Center"}}
+	candidates := []domain.Event{
+		{EventLink: "https://venue.example/other", Name: "Love of Lesbian", Date: start, Venue: domain.Venue{Name: "WiZink Center"}},
+		{EventLink: "https://venue.example/vetusta", Name: "Vetusta Morla en concierto", Date: start, Venue: domain.Venue{Name: "WiZink Center"}},
+	}
+
+	best, score, ok := New(config.DedupConfig{}).Best(event, candidates)
+	if !ok || best.EventLink != "https://venue.example/vetusta" {
+		t.Errorf("Best() = %s, %.2f, %v; want https://venue.example/vetusta", best.EventLink, score, ok)
+	}
+
+	// Отрицательный порог отключает поиск дубликатов
+	if _, _, ok := New(config.DedupConfig{Threshold: -1}).Best(event, candidates); ok {
+		t.Error("Best() with negative threshold found a duplicate")
+	}
+}
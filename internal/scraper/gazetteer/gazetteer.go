@@ -0,0 +1,99 @@
+// Package gazetteer — локальный справочник мест проведения с координатами.
+// Справочник ведётся вручную в YAML-файле и заменяет онлайн-геокодер: координаты мест
+// берутся только из него, поэтому ссылки на карту воспроизводимы и не зависят от внешних сервисов.
+package gazetteer
+
+import (
+	"fmt"
+	"os"
+
+	"eventsBot/internal/models/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File — содержимое файла справочника.
+type File struct {
+	Venues []Entry `yaml:"venues"` // Известные места
+}
+
+// Entry — место в справочнике.
+type Entry struct {
+	Name      string   `yaml:"name"`      // Название места
+	Aliases   []string `yaml:"aliases"`   // Другие написания названия и адреса, под которыми место встречается на сайтах
+	Address   string   `yaml:"address"`   // Адрес
+	Latitude  float64  `yaml:"latitude"`  // Широта
+	Longitude float64  `yaml:"longitude"` // Долгота
+}
+
+// Gazetteer ищет места по нормализованному названию, адресу или другому написанию.
+// Нулевое значение — пустой справочник.
+type Gazetteer struct {
+	entries map[string]Entry // Ключ — domain.NormalizeVenueName
+}
+
+// Load читает справочник из файла path. Пустой path даёт пустой справочник.
+func Load(path string) (*Gazetteer, error) {
+	if path == "" {
+		return &Gazetteer{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gazetteer: %w", err)
+	}
+
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode gazetteer: %w", err)
+	}
+
+	return New(file.Venues), nil
+}
+
+// New создаёт справочник из списка мест. Если одно написание встречается у нескольких мест,
+// используется первое из них.
+func New(entries []Entry) *Gazetteer {
+	g := &Gazetteer{entries: make(map[string]Entry)}
+	for _, entry := range entries {
+		for _, name := range append([]string{entry.Name, entry.Address}, entry.Aliases...) {
+			key := domain.NormalizeVenueName(name)
+			if key == "" {
+				continue
+			}
+			if _, ok := g.entries[key]; !ok {
+				g.entries[key] = entry
+			}
+		}
+	}
+	return g
+}
+
+// Len возвращает число написаний мест в справочнике.
+func (g *Gazetteer) Len() int {
+	return len(g.entries)
+}
+
+// Resolve ищет место venue в справочнике по названию, а затем по адресу. Найденное место
+// получает название, адрес и координаты из справочника; незаполненные в справочнике поля
+// остаются как в venue. Второе значение false, если место в справочнике не найдено.
+func (g *Gazetteer) Resolve(venue domain.Venue) (domain.Venue, bool) {
+	for _, name := range []string{venue.Name, venue.Address} {
+		entry, ok := g.entries[domain.NormalizeVenueName(name)]
+		if !ok {
+			continue
+		}
+
+		if entry.Name != "" {
+			venue.Name = entry.Name
+		}
+		if entry.Address != "" {
+			venue.Address = entry.Address
+		}
+		if entry.Latitude != 0 || entry.Longitude != 0 {
+			venue.Latitude, venue.Longitude = entry.Latitude, entry.Longitude
+		}
+		return venue, true
+	}
+	return venue, false
+}
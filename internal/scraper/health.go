@@ -0,0 +1,156 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/scraper/sites"
+
+	"github.com/google/uuid"
+)
+
+// Значения по умолчанию для проверок работоспособности скрапера (SiteConfig.Health).
+const (
+	defaultMinEvents          = 1
+	defaultMaxIncompleteShare = 0.2
+	defaultMaxDropShare       = 0.5
+	// previousRunsLookup — сколько последних запусков просматривать в поиске успешного для сравнения.
+	previousRunsLookup = 5
+)
+
+// healthPolicy возвращает проверки работоспособности сайта с подставленными значениями по умолчанию.
+func healthPolicy(site config.SiteConfig) config.HealthConfig {
+	policy := site.Health
+	if policy.MinEvents == 0 {
+		policy.MinEvents = defaultMinEvents
+	}
+	if len(policy.RequiredFields) == 0 {
+		policy.RequiredFields = []string{domain.SourceFieldName, domain.SourceFieldDate}
+		if site.Type == "feed" {
+			policy.RequiredFields = []string{domain.SourceFieldName}
+		}
+	}
+	if policy.MaxIncompleteShare == 0 {
+		policy.MaxIncompleteShare = defaultMaxIncompleteShare
+	}
+	if policy.MaxDropShare == 0 {
+		policy.MaxDropShare = defaultMaxDropShare
+	}
+	return policy
+}
+
+// checkHealth проверяет результат запуска: число событий, долю событий без обязательных полей
+// и падение числа событий относительно предыдущего успешного запуска previous (может быть nil).
+// Страницы, из которых скрапер не извлёк событие (unparsed), считаются событиями без обязательных полей.
+// Заполняет IncompleteCount, Problems и Status запуска.
+func checkHealth(policy config.HealthConfig, run *domain.ScrapeRun, events []domain.Event, unparsed int, previous *domain.ScrapeRun) {
+	run.IncompleteCount += unparsed
+	for _, event := range events {
+		source := domain.NewSourceSnapshot(event)
+		for _, field := range policy.RequiredFields {
+			if source[field] == "" {
+				run.IncompleteCount++
+				break
+			}
+		}
+	}
+
+	if policy.MinEvents > 0 && run.EventsCount < policy.MinEvents {
+		run.Problems = append(run.Problems,
+			fmt.Sprintf("найдено событий: %d, ожидалось не меньше %d", run.EventsCount, policy.MinEvents))
+	}
+
+	if total := len(events) + unparsed; policy.MaxIncompleteShare > 0 && total > 0 {
+		share := float64(run.IncompleteCount) / float64(total)
+		if share > policy.MaxIncompleteShare {
+			run.Problems = append(run.Problems,
+				fmt.Sprintf("без обязательных полей (%s): %d из %d событий", strings.Join(policy.RequiredFields, ", "), run.IncompleteCount, total))
+		}
+	}
+
+	if policy.MaxDropShare > 0 && previous != nil && previous.EventsCount > 0 {
+		if float64(run.EventsCount) < float64(previous.EventsCount)*(1-policy.MaxDropShare) {
+			run.Problems = append(run.Problems,
+				fmt.Sprintf("число событий упало с %d до %d по сравнению с предыдущим запуском", previous.EventsCount, run.EventsCount))
+		}
+	}
+
+	run.Status = domain.ScrapeRunStatusOK
+	if len(run.Problems) > 0 {
+		run.Status = domain.ScrapeRunStatusDegraded
+	}
+}
+
+// recordRun проверяет работоспособность скрапера по результату запуска, сохраняет запуск
+// в историю вместе с итогами обработки событий outcomes и при изменении состояния сайта
+// (сломался или восстановился) отправляет оповещение админам.
+func (s *Scraper) recordRun(log *slog.Logger, job Job, startedAt time.Time, result sites.Result, outcomes map[eventOutcome]int, scrapeErr error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	run := domain.ScrapeRun{
+		ID:           uuid.New(),
+		RequestID:    job.requestID,
+		SiteName:     job.siteName,
+		EventsCount:  len(result.Events) + len(result.Skipped) + len(result.Blocked) + len(result.Unparsed),
+		SkippedCount: len(result.Skipped),
+		BlockedCount: len(result.Blocked),
+		// Страницы известных событий не скачивались — они тоже дубликаты
+		NewCount:       outcomes[outcomeCreated],
+		ChangedCount:   outcomes[outcomeChanged],
+		DuplicateCount: outcomes[outcomeUnchanged] + len(result.Skipped),
+		FailedCount:    outcomes[outcomeFailed],
+		StartedAt:      startedAt,
+		FinishedAt:     time.Now(),
+	}
+
+	previousRuns, err := s.repository.FindScrapeRuns(ctx, domain.ScrapeRunFilter{SiteName: job.siteName, Limit: previousRunsLookup})
+	if err != nil {
+		log.Error("failed to find previous scrape runs", slog.String("error", err.Error()))
+	}
+
+	// Для сравнения числа событий берём последний запуск, завершившийся без ошибки
+	var previousOK *domain.ScrapeRun
+	for i := range previousRuns {
+		if previousRuns[i].Status != domain.ScrapeRunStatusFailed {
+			previousOK = &previousRuns[i]
+			break
+		}
+	}
+
+	if scrapeErr != nil {
+		run.Status = domain.ScrapeRunStatusFailed
+		run.Error = scrapeErr.Error()
+	} else {
+		checkHealth(healthPolicy(s.siteConfigs[job.siteName]), &run, result.Events, len(result.Unparsed), previousOK)
+	}
+
+	if err := s.repository.CreateScrapeRun(ctx, run); err != nil {
+		log.Error("failed to save scrape run", slog.String("error", err.Error()))
+	}
+
+	if run.Status == domain.ScrapeRunStatusDegraded {
+		log.Warn("scrape run is degraded", slog.Any("problems", run.Problems))
+	}
+
+	var previousStatus domain.ScrapeRunStatus
+	if len(previousRuns) > 0 {
+		previousStatus = previousRuns[0].Status
+	}
+
+	// Оповещаем только о смене состояния: первый сбой и восстановление после него
+	if run.Status == previousStatus || (run.Status == domain.ScrapeRunStatusOK && previousStatus == "") {
+		return
+	}
+
+	select {
+	case s.HealthAlertsChan <- domain.ScrapeRunAlert{Run: run, PreviousStatus: previousStatus}:
+	default:
+		log.Warn("HealthAlertsChan is full, skipping admin notification")
+	}
+}
@@ -0,0 +1,93 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+)
+
+func TestCheckHealth(t *testing.T) {
+	complete := domain.Event{Name: "Concert", Date: time.Date(2025, 3, 21, 20, 0, 0, 0, time.UTC), EventLink: "https://example.com/a"}
+	noDate := domain.Event{Name: "No date", EventLink: "https://example.com/b"}
+
+	repeat := func(event domain.Event, n int) []domain.Event {
+		events := make([]domain.Event, n)
+		for i := range events {
+			events[i] = event
+		}
+		return events
+	}
+
+	tests := []struct {
+		name           string
+		events         []domain.Event
+		unparsed       int
+		previous       *domain.ScrapeRun
+		wantIncomplete int
+		wantStatus     domain.ScrapeRunStatus
+		wantProblems   int
+	}{
+		{
+			name:       "healthy run",
+			events:     repeat(complete, 10),
+			previous:   &domain.ScrapeRun{EventsCount: 10},
+			wantStatus: domain.ScrapeRunStatusOK,
+		},
+		{
+			name:         "no events",
+			wantStatus:   domain.ScrapeRunStatusDegraded,
+			wantProblems: 1,
+		},
+		{
+			name:           "events without date over the share",
+			events:         append(repeat(complete, 7), repeat(noDate, 3)...),
+			wantIncomplete: 3,
+			wantStatus:     domain.ScrapeRunStatusDegraded,
+			wantProblems:   1,
+		},
+		{
+			name:           "few events without date",
+			events:         append(repeat(complete, 9), noDate),
+			wantIncomplete: 1,
+			wantStatus:     domain.ScrapeRunStatusOK,
+		},
+		{
+			// Разметка изменилась: у большинства страниц не нашлось названия
+			name:           "pages without name count as incomplete",
+			events:         repeat(complete, 2),
+			unparsed:       8,
+			previous:       &domain.ScrapeRun{EventsCount: 10},
+			wantIncomplete: 8,
+			wantStatus:     domain.ScrapeRunStatusDegraded,
+			wantProblems:   1,
+		},
+		{
+			name:         "drop against previous run",
+			events:       repeat(complete, 4),
+			previous:     &domain.ScrapeRun{EventsCount: 10},
+			wantStatus:   domain.ScrapeRunStatusDegraded,
+			wantProblems: 1,
+		},
+	}
+
+	policy := healthPolicy(config.SiteConfig{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := domain.ScrapeRun{EventsCount: len(tt.events) + tt.unparsed}
+			checkHealth(policy, &run, tt.events, tt.unparsed, tt.previous)
+
+			if run.IncompleteCount != tt.wantIncomplete {
+				t.Errorf("IncompleteCount = %d, want %d", run.IncompleteCount, tt.wantIncomplete)
+			}
+			if run.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", run.Status, tt.wantStatus)
+			}
+			if len(run.Problems) != tt.wantProblems {
+				t.Errorf("Problems = %q, want %d problems", run.Problems, tt.wantProblems)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package replay
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"time"
+
+	"eventsBot/internal/config"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Имена файлов фикстуры сайта.
+const (
+	fixtureFile = "fixture.yml" // Описание фикстуры и список страниц
+	goldenFile  = "golden.json" // Ожидаемые события
+	pagesDir    = "pages"       // Каталог с сохранёнными страницами
+)
+
+// Fixture — записанные ответы сайта для воспроизведения скрапера без сети.
+type Fixture struct {
+	Site       config.SiteConfig `yaml:"site"`       // Конфигурация сайта, с которой выполнялась запись
+	RecordedAt time.Time         `yaml:"recordedAt"` // Время записи
+	Pages      []Page            `yaml:"pages"`      // Записанные ответы
+}
+
+// Page — записанный ответ на запрос страницы.
+type Page struct {
+	URL         string `yaml:"url"`                // Исходный URL запроса
+	Status      int    `yaml:"status"`             // Код ответа
+	ContentType string `yaml:"contentType"`        // Заголовок Content-Type
+	Location    string `yaml:"location,omitempty"` // Заголовок Location для редиректов
+	File        string `yaml:"file"`               // Файл с телом ответа относительно каталога фикстуры
+}
+
+// LoadFixture читает фикстуру из каталога dir.
+func LoadFixture(dir string) (Fixture, error) {
+	var fixture Fixture
+
+	data, err := os.ReadFile(filepath.Join(dir, fixtureFile))
+	if err != nil {
+		return fixture, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &fixture); err != nil {
+		return fixture, fmt.Errorf("failed to decode fixture: %w", err)
+	}
+
+	return fixture, nil
+}
+
+// save записывает описание фикстуры в каталог dir.
+func (f Fixture) save(dir string) error {
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, fixtureFile), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// pageFile возвращает имя файла для тела ответа: хеш URL и расширение по Content-Type.
+func pageFile(rawURL string, contentType string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+
+	ext := ".bin"
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch mediaType {
+		case "text/html":
+			ext = ".html"
+		case "application/json", "application/ld+json":
+			ext = ".json"
+		case "text/calendar":
+			ext = ".ics"
+		case "application/rss+xml", "application/atom+xml", "application/xml", "text/xml":
+			ext = ".xml"
+		case "text/plain":
+			ext = ".txt"
+		}
+	}
+
+	return filepath.Join(pagesDir, hex.EncodeToString(sum[:8])+ext)
+}
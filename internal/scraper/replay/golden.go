@@ -0,0 +1,109 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"eventsBot/internal/models/domain"
+)
+
+// normalize приводит события к виду, не зависящему от порядка обхода страниц.
+func normalize(events []domain.Event) []domain.Event {
+	out := slices.Clone(events)
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].Date.Equal(out[j].Date) {
+			return out[i].Date.Before(out[j].Date)
+		}
+		if out[i].EventLink != out[j].EventLink {
+			return out[i].EventLink < out[j].EventLink
+		}
+		return out[i].Name < out[j].Name
+	})
+	if out == nil {
+		out = []domain.Event{}
+	}
+	return out
+}
+
+// WriteGolden сохраняет события как ожидаемый результат фикстуры из каталога dir.
+func WriteGolden(dir string, events []domain.Event) error {
+	data, err := json.MarshalIndent(normalize(events), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode golden events: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, goldenFile), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write golden events: %w", err)
+	}
+	return nil
+}
+
+// CompareGolden сравнивает события с ожидаемым результатом фикстуры из каталога dir.
+// Возвращает описание расхождений или пустую строку, если результат совпал.
+func CompareGolden(dir string, events []domain.Event) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, goldenFile))
+	if err != nil {
+		return "", fmt.Errorf("failed to read golden events: %w", err)
+	}
+
+	var want []map[string]any
+	if err := json.Unmarshal(data, &want); err != nil {
+		return "", fmt.Errorf("failed to decode golden events: %w", err)
+	}
+
+	// События сравниваются в JSON-представлении, как они лежат в golden-файле
+	gotData, err := json.Marshal(normalize(events))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode events: %w", err)
+	}
+	var got []map[string]any
+	if err := json.Unmarshal(gotData, &got); err != nil {
+		return "", fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	return diffEvents(want, got), nil
+}
+
+// diffEvents описывает расхождения между ожидаемыми и полученными событиями по полям.
+func diffEvents(want, got []map[string]any) string {
+	var sb strings.Builder
+
+	if len(want) != len(got) {
+		fmt.Fprintf(&sb, "events count: want %d, got %d\n", len(want), len(got))
+	}
+
+	for i := 0; i < max(len(want), len(got)); i++ {
+		switch {
+		case i >= len(got):
+			fmt.Fprintf(&sb, "event %d %q: missing\n", i, want[i]["Name"])
+			continue
+		case i >= len(want):
+			fmt.Fprintf(&sb, "event %d %q: unexpected\n", i, got[i]["Name"])
+			continue
+		}
+
+		keys := make([]string, 0, len(want[i]))
+		for key := range want[i] {
+			keys = append(keys, key)
+		}
+		for key := range got[i] {
+			if _, ok := want[i][key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if !reflect.DeepEqual(want[i][key], got[i][key]) {
+				fmt.Fprintf(&sb, "event %d %q: %s: want %v, got %v\n", i, want[i]["Name"], key, want[i][key], got[i][key])
+			}
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,81 @@
+package replay
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// recorder — http.RoundTripper, сохраняющий тела ответов в каталог фикстуры.
+type recorder struct {
+	base http.RoundTripper
+	dir  string
+
+	mu    sync.Mutex
+	pages map[string]Page // Записанные ответы по URL
+	order []string        // URL в порядке первого запроса
+	err   error           // Первая ошибка записи
+}
+
+// newRecorder создаёт recorder, выполняющий запросы через base.
+func newRecorder(base http.RoundTripper, dir string) *recorder {
+	return &recorder{
+		base:  base,
+		dir:   dir,
+		pages: make(map[string]Page),
+	}
+}
+
+// RoundTrip выполняет запрос и сохраняет тело ответа.
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", req.URL, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	page := Page{
+		URL:         req.URL.String(),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if location, err := resp.Location(); err == nil {
+		page.Location = location.String()
+	}
+	page.File = pageFile(page.URL, page.ContentType)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.WriteFile(filepath.Join(r.dir, page.File), body, 0o644); err != nil && r.err == nil {
+		r.err = fmt.Errorf("failed to save %s: %w", page.URL, err)
+	}
+	if _, seen := r.pages[page.URL]; !seen {
+		r.order = append(r.order, page.URL)
+	}
+	r.pages[page.URL] = page
+
+	return resp, nil
+}
+
+// recorded возвращает записанные страницы в порядке запросов.
+func (r *recorder) recorded() ([]Page, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pages := make([]Page, 0, len(r.order))
+	for _, u := range r.order {
+		pages = append(pages, r.pages[u])
+	}
+	return pages, r.err
+}
@@ -0,0 +1,129 @@
+// Package replay записывает ответы сайтов в фикстуры и воспроизводит их скраперам
+// через локальный httptest-сервер, чтобы проверять скраперы без сети по golden-файлам.
+//
+// Каталог фикстуры сайта содержит fixture.yml (конфигурация сайта и список страниц),
+// каталог pages с телами ответов и golden.json с ожидаемыми событиями.
+package replay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/scraper/sites"
+)
+
+// replayFetchConfig — настройки загрузки при воспроизведении: без пауз и повторов.
+var replayFetchConfig = config.FetchConfig{
+	Concurrency: 4,
+	Retries:     -1,
+	RateLimit:   1000,
+}
+
+// Record скачивает страницы сайта с настройками загрузки cfg, сохраняет их в каталог dir
+// и записывает полученные события как ожидаемый результат.
+func Record(ctx context.Context, site config.SiteConfig, cfg config.FetchConfig, dir string) ([]domain.Event, error) {
+	if err := os.MkdirAll(filepath.Join(dir, pagesDir), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create fixture dir: %w", err)
+	}
+
+	rec := newRecorder(http.DefaultTransport, dir)
+	events, err := scrape(ctx, site, sites.NewFetcherWithTransport(cfg, rec))
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := rec.recorded()
+	if err != nil {
+		return nil, err
+	}
+
+	// Заголовки API могут содержать токены доступа — в фикстуру они не попадают
+	if len(site.API.Headers) > 0 {
+		headers := make(map[string]string, len(site.API.Headers))
+		for key := range site.API.Headers {
+			headers[key] = "REDACTED"
+		}
+		site.API.Headers = headers
+	}
+
+	fixture := Fixture{
+		Site:       site,
+		RecordedAt: time.Now().UTC().Truncate(time.Second),
+		Pages:      pages,
+	}
+	if err := fixture.save(dir); err != nil {
+		return nil, err
+	}
+	if err := WriteGolden(dir, events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Run воспроизводит фикстуру из каталога dir и возвращает события, которые извлёк скрапер.
+func Run(ctx context.Context, dir string) ([]domain.Event, error) {
+	fixture, err := LoadFixture(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	server := NewServer(fixture, dir)
+	defer server.Close()
+
+	return scrape(ctx, fixture.Site, sites.NewFetcherWithTransport(replayFetchConfig, server.Transport()))
+}
+
+// Check воспроизводит фикстуру из каталога dir и сравнивает результат с golden.json.
+// Если update, golden.json перезаписывается полученными событиями.
+// Возвращает описание расхождений или пустую строку, если результат совпал.
+func Check(ctx context.Context, dir string, update bool) (string, error) {
+	events, err := Run(ctx, dir)
+	if err != nil {
+		return "", err
+	}
+
+	if update {
+		return "", WriteGolden(dir, events)
+	}
+	return CompareGolden(dir, events)
+}
+
+// FixtureDirs возвращает каталоги фикстур внутри root (по одному на сайт).
+func FixtureDirs(root string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(root, "*", fixtureFile))
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		dirs = append(dirs, filepath.Dir(match))
+	}
+	return dirs, nil
+}
+
+// scrape запускает скрапер сайта через fetcher. Известных событий нет: скачиваются все страницы.
+func scrape(ctx context.Context, site config.SiteConfig, fetcher *sites.Fetcher) ([]domain.Event, error) {
+	scrapeFunc, err := sites.New(site)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scraper for %s: %w", site.Name, err)
+	}
+
+	result, err := scrapeFunc(ctx, sites.Request{
+		URL:      site.URL,
+		Shutdown: make(chan struct{}),
+		Fetcher:  fetcher,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape %s: %w", site.Name, err)
+	}
+
+	return result.Events, nil
+}
@@ -0,0 +1,105 @@
+package replay
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"eventsBot/internal/config"
+)
+
+// update перезаписывает golden-файлы результатом скраперов:
+//
+//	go test ./internal/scraper/replay -update
+var update = flag.Bool("update", false, "rewrite golden files with current scraper output")
+
+// TestFixtures воспроизводит каждую фикстуру из testdata и сверяет события с её golden.json.
+func TestFixtures(t *testing.T) {
+	dirs, err := FixtureDirs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirs) == 0 {
+		t.Fatal("no fixtures found in testdata")
+	}
+
+	for _, dir := range dirs {
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			diff, err := Check(ctx, dir, *update)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff != "" {
+				t.Errorf("events differ from %s (run with -update after intentional changes):\n%s",
+					filepath.Join(dir, goldenFile), diff)
+			}
+		})
+	}
+}
+
+// TestRecordReplay записывает сайт с локального сервера и проверяет, что воспроизведение
+// записанной фикстуры даёт те же события без обращения к сайту.
+func TestRecordReplay(t *testing.T) {
+	pages := map[string]string{
+		"/events/": `<html><body>
+			<article class="mec-event-article"><a class="mec-color-hover" href="/events/jazz-jam/">Jazz Jam</a></article>
+		</body></html>`,
+		"/events/jazz-jam/": `<html><body>
+			<h1 class="mec-single-title">Jazz Jam</h1>
+			<div class="mec-single-event-description"><p>Open jam session.</p></div>
+			<div class="mec-single-event-date"><span class="mec-start-date-label">04 Apr 2025</span></div>
+			<div class="mec-single-event-time"><abbr class="mec-events-abbr">22:00</abbr></div>
+			<dd class="mec-events-event-cost">5€</dd>
+		</body></html>`,
+	}
+
+	var requests atomic.Int32
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+		fmt.Fprint(w, body)
+	}))
+	defer site.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	siteCfg := config.SiteConfig{Name: "lococlub", URL: site.URL + "/events/"}
+
+	recorded, err := Record(ctx, siteCfg, replayFetchConfig, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded) != 1 || recorded[0].Name != "Jazz Jam" {
+		t.Fatalf("recorded events = %+v, want one event \"Jazz Jam\"", recorded)
+	}
+
+	site.Close()
+	recordedRequests := requests.Load()
+
+	diff, err := Check(ctx, dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("replayed events differ from recorded:\n%s", diff)
+	}
+	if n := requests.Load() - recordedRequests; n != 0 {
+		t.Errorf("replay made %d requests to the site", n)
+	}
+}
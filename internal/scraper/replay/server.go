@@ -0,0 +1,87 @@
+package replay
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// originalURLHeader — заголовок, в котором Transport передаёт серверу исходный URL запроса.
+const originalURLHeader = "X-Replay-Url"
+
+// Server — локальный httptest-сервер, отдающий записанные страницы фикстуры.
+// Страницы, которых нет в фикстуре, отдаются с кодом 404.
+type Server struct {
+	*httptest.Server
+	dir   string
+	pages map[string]Page
+}
+
+// NewServer запускает сервер со страницами фикстуры из каталога dir.
+func NewServer(fixture Fixture, dir string) *Server {
+	s := &Server{
+		dir:   dir,
+		pages: make(map[string]Page, len(fixture.Pages)),
+	}
+	for _, page := range fixture.Pages {
+		s.pages[page.URL] = page
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// serve отдаёт записанный ответ на исходный URL запроса.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	page, ok := s.pages[r.Header.Get(originalURLHeader)]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := os.ReadFile(filepath.Join(s.dir, page.File))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if page.ContentType != "" {
+		w.Header().Set("Content-Type", page.ContentType)
+	}
+	if page.Location != "" {
+		w.Header().Set("Location", page.Location)
+	}
+	w.WriteHeader(page.Status)
+	w.Write(body)
+}
+
+// Transport возвращает http.RoundTripper, направляющий все запросы на сервер
+// независимо от исходного домена. Исходный URL передаётся в заголовке.
+func (s *Server) Transport() http.RoundTripper {
+	target, _ := url.Parse(s.URL)
+	return &transport{target: target, base: s.Client().Transport}
+}
+
+// transport переписывает адрес запроса на адрес локального сервера.
+type transport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+// RoundTrip отправляет запрос на локальный сервер.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	replayReq := req.Clone(req.Context())
+	replayReq.Header.Set(originalURLHeader, req.URL.String())
+	replayReq.URL.Scheme = t.target.Scheme
+	replayReq.URL.Host = t.target.Host
+	replayReq.Host = t.target.Host
+
+	resp, err := t.base.RoundTrip(replayReq)
+	if err != nil {
+		return nil, err
+	}
+	// Клиент должен видеть ответ на исходный запрос, чтобы корректно разрешать ссылки и редиректы
+	resp.Request = req
+	return resp, nil
+}
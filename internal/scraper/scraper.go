@@ -2,23 +2,75 @@ package scraper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"time"
 
 	"eventsBot/internal/config"
+	"eventsBot/internal/images"
 	"eventsBot/internal/models/domain"
+	"eventsBot/internal/scraper/dedup"
+	"eventsBot/internal/scraper/gazetteer"
 	"eventsBot/internal/scraper/sites"
 
 	"github.com/google/uuid"
 )
 
 type Repository interface {
-	CreateEvent(ctx context.Context, event domain.Event) (domain.Event, error)
+	CreateEvent(ctx context.Context, event domain.Event) (domain.Event, domain.UpsertResult, error)
 	FindEventByLinkAndDate(ctx context.Context, link string, date time.Time) (domain.Event, error)
+	FindEventByLink(ctx context.Context, link string) (domain.Event, error)
+	FindEventsByLink(ctx context.Context, link string) ([]domain.Event, error)
+	FindUpcomingEventsByLink(ctx context.Context, link string) ([]domain.Event, error)
+	UpdateEventFromSource(ctx context.Context, event domain.Event) (domain.Event, error)
+	MarkEventsSeen(ctx context.Context, siteName string, ids []uuid.UUID) error
+	MarkEventsChecked(ctx context.Context, ids []uuid.UUID) error
+	IsEventCheckedSince(ctx context.Context, link string, since time.Time) (bool, error)
+	FindMissingEvents(ctx context.Context, siteName string, seen []uuid.UUID) ([]domain.Event, error)
+	FindStatusHistory(ctx context.Context, eventID uuid.UUID) ([]domain.StatusChange, error)
+	IncrementMissedScrapes(ctx context.Context, eventID uuid.UUID) (int, error)
+	UpdateEventStatus(ctx context.Context, eventID uuid.UUID, status string, reason string) error
+	CreateScrapeRun(ctx context.Context, run domain.ScrapeRun) error
+	FindScrapeRuns(ctx context.Context, filter domain.ScrapeRunFilter) ([]domain.ScrapeRun, error)
+	FindOrCreateSeries(ctx context.Context, series domain.EventSeries) (domain.EventSeries, error)
+	SetEventSeries(ctx context.Context, eventID uuid.UUID, seriesID uuid.UUID) error
+	ResetSeriesEnrichment(ctx context.Context, id uuid.UUID) error
+	FindOrCreateVenue(ctx context.Context, venue domain.Venue) (domain.Venue, error)
+	SetEventVenue(ctx context.Context, eventID uuid.UUID, venueID uuid.UUID, mapLink string) error
+	SaveImage(ctx context.Context, image domain.Image) error
+	FindImageBySourceURL(ctx context.Context, sourceURL string) (domain.Image, error)
+	FindDuplicateCandidates(ctx context.Context, event domain.Event, window time.Duration) ([]domain.Event, error)
 }
 
+// Политики обработки известного события, изменившегося на сайте (SiteConfig.OnChange).
+const (
+	// ChangePolicyEnrich — обновить поля и заново отправить событие в AI
+	ChangePolicyEnrich = "enrich"
+	// ChangePolicyModerate — обновить поля и вернуть событие на модерацию
+	ChangePolicyModerate = "moderate"
+	// ChangePolicyKeep — обновить поля, не меняя статус
+	ChangePolicyKeep = "keep"
+)
+
+// defaultCancelAfterMisses — число скрапингов подряд без события, после которого оно отменяется.
+const defaultCancelAfterMisses = 3
+
+// defaultRefreshAfter — как долго не скачивать заново страницы уже известных событий.
+const defaultRefreshAfter = 24 * time.Hour
+
+// eventOutcome — итог обработки найденного события (для статистики запуска скрапера).
+type eventOutcome string
+
+const (
+	outcomeCreated   eventOutcome = "created"   // Новое событие
+	outcomeChanged   eventOutcome = "changed"   // Известное событие изменилось на сайте
+	outcomeUnchanged eventOutcome = "unchanged" // Известное событие без изменений (дубликат)
+	outcomeFailed    eventOutcome = "failed"    // Событие не удалось сохранить
+)
+
 // Job представляет задачу, передаваемую в воркер.
 type Job struct {
 	requestID uuid.UUID     // Уникальный идентификатор запроса
@@ -32,9 +84,16 @@ type Scraper struct {
 	logger              *slog.Logger
 	cfg                 *config.Config
 	repository          Repository
-	scrapers            map[string]sites.ScrapeFunc // Регистр site-specific скраперов
+	scrapers            map[string]sites.ScrapeFunc  // Регистр site-specific скраперов
+	siteConfigs         map[string]config.SiteConfig // Настройки сайтов по имени
+	fetcher             *sites.Fetcher               // Общий слой загрузки страниц для всех скраперов
+	gazetteer           *gazetteer.Gazetteer         // Справочник мест с координатами
+	images              *images.Store                // Локальное хранилище фото событий; nil, если недоступно
+	dedup               *dedup.Matcher               // Поиск того же мероприятия на других сайтах
 	jobs                chan Job
-	CompletedEventsChan chan domain.Event // Канал для завершённых событий (для передачи в AI)
+	CompletedEventsChan chan domain.Event             // Канал для завершённых событий (для передачи в AI)
+	CancelledEventsChan chan domain.EventCancellation // Канал для отменённых событий (для уведомления админов)
+	HealthAlertsChan    chan domain.ScrapeRunAlert    // Канал оповещений о поломке и восстановлении скраперов (для админов)
 	shutdownChannel     chan struct{}
 	wg                  *sync.WaitGroup
 }
@@ -57,18 +116,88 @@ func New(
 		cfg:                 cfg,
 		repository:          repository,
 		scrapers:            make(map[string]sites.ScrapeFunc),
+		siteConfigs:         make(map[string]config.SiteConfig),
+		fetcher:             sites.NewFetcher(cfg.ScraperConfig.Fetch),
+		gazetteer:           &gazetteer.Gazetteer{},
+		dedup:               dedup.New(cfg.ScraperConfig.Dedup),
 		jobs:                make(chan Job, cfg.ScraperConfig.JobBufferSize),
 		CompletedEventsChan: make(chan domain.Event, 100),
+		CancelledEventsChan: make(chan domain.EventCancellation, 100),
+		HealthAlertsChan:    make(chan domain.ScrapeRunAlert, 100),
 		shutdownChannel:     make(chan struct{}),
 		wg:                  &sync.WaitGroup{},
 	}
 
-	// Регистрация скраперов
-	s.scrapers["lococlub"] = sites.ScrapeLococlub
+	if places, err := gazetteer.Load(cfg.ScraperConfig.Gazetteer); err != nil {
+		log.Error("failed to load gazetteer, venues will have no coordinates",
+			slog.String("path", cfg.ScraperConfig.Gazetteer),
+			slog.String("error", err.Error()),
+		)
+	} else {
+		s.gazetteer = places
+		log.Info("gazetteer loaded", slog.Int("names", places.Len()))
+	}
+
+	if store, err := images.NewStore(cfg.Images, s.fetcher); err != nil {
+		log.Error("failed to create image store, photos will be sent by link",
+			slog.String("dir", cfg.Images.Dir),
+			slog.String("error", err.Error()),
+		)
+	} else {
+		s.images = store
+	}
+
+	// Регистрация скраперов сайтов из конфигурации; встроенные скраперы подключаются через реестр sites.New
+	for _, site := range cfg.ScraperConfig.Sites {
+		switch site.OnChange {
+		case "", ChangePolicyEnrich, ChangePolicyModerate, ChangePolicyKeep:
+		default:
+			log.Error("unknown onChange policy, using default",
+				slog.String("name", site.Name),
+				slog.String("onChange", site.OnChange),
+			)
+			site.OnChange = ""
+		}
+		s.siteConfigs[site.Name] = site
+
+		if err := s.fetcher.SetSiteLimits(site.URL, site.RateLimit, site.CrawlDelay); err != nil {
+			log.Error("failed to set site rate limits",
+				slog.String("name", site.Name),
+				slog.String("error", err.Error()),
+			)
+		}
+		if site.API.URLTemplate != "" {
+			if err := s.fetcher.SetSiteLimits(site.API.URLTemplate, site.RateLimit, site.CrawlDelay); err != nil {
+				log.Error("failed to set api rate limits",
+					slog.String("name", site.Name),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+
+		if err := s.registerSite(site); err != nil {
+			log.Error("failed to register site scraper",
+				slog.String("name", site.Name),
+				slog.String("type", site.Type),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
 
 	return s
 }
 
+// registerSite регистрирует скрапер для сайта с указанным в конфигурации типом.
+// Сайты без типа используют скрапер, написанный под сайт с тем же именем.
+func (s *Scraper) registerSite(site config.SiteConfig) error {
+	scrapeFunc, err := sites.New(site)
+	if err != nil {
+		return err
+	}
+	s.scrapers[site.Name] = scrapeFunc
+	return nil
+}
+
 // Start запускает воркеры для обработки задач.
 func (s *Scraper) Start() {
 	op := "Scraper.Start()"
@@ -135,66 +264,665 @@ func (s *Scraper) handleJob(id int) {
 			scrapeFunc, exists := s.scrapers[job.siteName]
 			if !exists {
 				joblog.Error("scraper not found for site", slog.String("siteName", job.siteName))
+				s.recordRun(joblog, job, time.Now(), sites.Result{}, nil, fmt.Errorf("scraper not found for site %s", job.siteName))
 				close(job.Done)
 				continue
 			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(s.cfg.ScraperConfig.Timeout)*time.Second)
+			// Изменения событий записываются в историю от имени скрапера сайта
+			ctx = domain.WithActor(ctx, domain.Actor{Type: domain.ActorScraper, ID: job.siteName})
 
-			events, err := scrapeFunc(ctx, job.url, s.shutdownChannel)
+			startedAt := time.Now()
+			result, err := scrapeFunc(ctx, sites.Request{
+				URL:      job.url,
+				Shutdown: s.shutdownChannel,
+				Fetcher:  s.fetcher,
+				IsKnown:  s.knownChecker(joblog, job.siteName),
+			})
 
 			if err != nil {
 				cancel()
-				joblog.Error("scraping failed", slog.String("error", err.Error()))
+				var blockedErr *sites.BlockedError
+				if errors.As(err, &blockedErr) {
+					joblog.Warn("site page is disallowed by robots.txt", slog.String("url", blockedErr.URL))
+				} else {
+					joblog.Error("scraping failed", slog.String("error", err.Error()))
+				}
+				s.recordRun(joblog, job, startedAt, result, nil, err)
 				close(job.Done)
 				continue
 			}
 
+			for _, link := range result.Blocked {
+				joblog.Warn("event page is disallowed by robots.txt", slog.String("link", link))
+			}
+			for _, link := range result.Unparsed {
+				joblog.Warn("no event found on event page", slog.String("link", link))
+			}
+			if result.FailedErr != nil {
+				joblog.Warn("failed to fetch event pages",
+					slog.Int("failedCount", len(result.Failed)),
+					slog.String("error", result.FailedErr.Error()),
+				)
+			}
+			if result.Truncated {
+				joblog.Warn("scrape stopped at the page limit, results are incomplete")
+			}
+
+			events := result.Events
+
+			// Ссылки, которые встречаются в выдаче один раз: для них событие со сменившейся
+			// датой можно найти по одной ссылке
+			linkCounts := make(map[string]int, len(events))
+			for _, event := range events {
+				linkCounts[event.EventLink]++
+			}
+
 			// Обрабатываем каждое событие
+			checked := make([]uuid.UUID, 0, len(events))
+			outcomes := make(map[eventOutcome]int)
+			var unsaved []string
 			for _, event := range events {
-				// Проверяем, есть ли уже такое событие в БД
-				existing, err := s.repository.FindEventByLinkAndDate(ctx, event.EventLink, event.Date)
-				if err == nil && existing.ID != uuid.Nil {
-					joblog.Debug("event already exists", slog.String("link", event.EventLink))
-					continue
+				id, outcome := s.processEvent(ctx, joblog, job.siteName, event, linkCounts[event.EventLink] == 1)
+				outcomes[outcome]++
+				if id != uuid.Nil {
+					checked = append(checked, id)
+				} else if event.EventLink != "" {
+					unsaved = append(unsaved, event.EventLink)
 				}
+			}
+			if err := s.repository.MarkEventsChecked(ctx, checked); err != nil {
+				joblog.Error("failed to mark events as checked", slog.String("error", err.Error()))
+			}
 
-				// Сохраняем событие со статусом NEW
-				event.ID = uuid.New()
-				event.Status = domain.EventStatusNew
-				savedEvent, err := s.repository.CreateEvent(ctx, event)
+			// События, страницы которых не скачивались, запрещены robots.txt или не разобраны
+			// (например, после изменения разметки), всё равно есть на сайте
+			seen := checked
+			for _, link := range slices.Concat(result.Skipped, result.Blocked, result.Unparsed) {
+				known, err := s.repository.FindEventsByLink(ctx, link)
 				if err != nil {
-					joblog.Error("failed to create event", slog.String("error", err.Error()))
+					joblog.Error("failed to find skipped event", slog.String("link", link), slog.String("error", err.Error()))
 					continue
 				}
+				for _, event := range known {
+					seen = append(seen, event.ID)
+					if event.Status == domain.EventStatusCancelled {
+						eventlog := joblog.With(slog.String("eventID", event.ID.String()), slog.String("link", event.EventLink))
+						event.Status, _ = s.restoreCancelled(ctx, eventlog, event)
+						s.enrichRestored(eventlog, event)
+					}
+				}
+			}
 
-				joblog.Debug("event created", slog.String("name", savedEvent.Name))
-
-				// Отправляем в канал для обработки AI
-				select {
-				case s.CompletedEventsChan <- savedEvent:
-				default:
-					joblog.Warn("CompletedEventsChan is full, skipping AI enrichment")
+			// События, страницы которых не удалось скачать или которые не удалось сохранить,
+			// тоже есть на сайте, но восстанавливать отменённые по ним нельзя: их состояние неизвестно
+			for _, link := range slices.Concat(result.Failed, unsaved) {
+				known, err := s.repository.FindEventsByLink(ctx, link)
+				if err != nil {
+					joblog.Error("failed to find unchecked event", slog.String("link", link), slog.String("error", err.Error()))
+					continue
+				}
+				for _, event := range known {
+					seen = append(seen, event.ID)
 				}
 			}
 
+			// Выдача неполна: пропавшие из неё события не считаются пропущенными
+			partial := len(result.Failed) > 0 || len(unsaved) > 0 || result.Truncated
+			s.trackMissingEvents(ctx, joblog, job.siteName, seen, partial)
+			s.recordRun(joblog, job, startedAt, result, outcomes, nil)
+
 			cancel() // Освобождаем контекст после обработки всех событий
 			close(job.Done)
 
-			joblog.Info("scraping completed", slog.Int("eventsCount", len(events)))
+			joblog.Info("scraping completed",
+				slog.Int("eventsCount", len(events)),
+				slog.Int("skippedCount", len(result.Skipped)),
+				slog.Int("blockedCount", len(result.Blocked)),
+				slog.Int("unparsedCount", len(result.Unparsed)),
+				slog.Int("failedCount", len(result.Failed)),
+			)
+		}
+	}
+}
+
+// processEvent сохраняет новое событие или сверяет известное с его состоянием на сайте.
+// Возвращает идентификатор события в БД (uuid.Nil, если событие сохранить не удалось) и итог обработки.
+func (s *Scraper) processEvent(ctx context.Context, log *slog.Logger, siteName string, event domain.Event, uniqueLink bool) (uuid.UUID, eventOutcome) {
+	source := domain.NewSourceSnapshot(event)
+	s.attachVenue(ctx, log, siteName, &event)
+
+	existing, found := s.findExisting(ctx, event, uniqueLink)
+	if found {
+		// Место проведения сохраняем и у событий, сохранённых до появления мест
+		if event.VenueID != uuid.Nil && existing.VenueID != event.VenueID {
+			if err := s.repository.SetEventVenue(ctx, existing.ID, event.VenueID, event.MapLink); err != nil {
+				log.Error("failed to set event venue", slog.String("error", err.Error()))
+			} else {
+				existing.VenueID, existing.MapLink = event.VenueID, event.MapLink
+			}
+		}
+		// События, сохранённые до появления серий, привязываем к серии при следующем скрапинге
+		if existing.SeriesID == uuid.Nil {
+			if series, ok := s.findSeries(ctx, log, siteName, existing); ok {
+				if err := s.repository.SetEventSeries(ctx, existing.ID, series.ID); err != nil {
+					log.Error("failed to set event series", slog.String("error", err.Error()))
+				} else {
+					existing.SeriesID = series.ID
+				}
+			}
+		}
+		return existing.ID, s.updateExisting(ctx, log, siteName, existing, event, source)
+	}
+
+	// Сохраняем событие со статусом NEW
+	event.ID = uuid.New()
+	event.Status = domain.EventStatusNew
+	event.SiteName = siteName
+	event.Source = source
+
+	// То же мероприятие уже сохранено с другого сайта: событие сохраняется как дубликат и не уходит
+	// в AI — публикуется каноническое событие со ссылками на все источники
+	canonical, score, duplicate := s.findDuplicate(ctx, log, event)
+	if duplicate {
+		event.CanonicalID = canonical.ID
+		event.DuplicateScore = score
+		event.Status = domain.EventStatusDuplicate
+	} else if series, ok := s.findSeries(ctx, log, siteName, event); ok {
+		// Другие даты того же мероприятия уже обогащены — берём содержимое серии вместо AI
+		event.SeriesID = series.ID
+		if series.IsEnriched() {
+			series.ApplyTo(&event)
+			if link := event.CalendarLink(); link != "" {
+				event.CalendarLinkAndroid = link
+			}
+			event.Status = domain.EventStatusAIEnriched
 		}
 	}
+
+	if !duplicate {
+		s.attachImage(ctx, log, &event)
+	}
+
+	savedEvent, result, err := s.repository.CreateEvent(ctx, event)
+	if err != nil {
+		log.Error("failed to create event", slog.String("error", err.Error()))
+		return uuid.Nil, outcomeFailed
+	}
+
+	// Событие с той же ссылкой и датой успел сохранить другой воркер (задачи сайта пересеклись)
+	switch result {
+	case domain.UpsertUnchanged:
+		log.Debug("event was saved concurrently and is unchanged", slog.String("eventID", savedEvent.ID.String()))
+		return savedEvent.ID, outcomeUnchanged
+	case domain.UpsertUpdated:
+		return savedEvent.ID, s.applyUpsertedChange(ctx, log, siteName, savedEvent)
+	}
+
+	if duplicate {
+		log.Info("event is a duplicate of an event from another source",
+			slog.String("name", savedEvent.Name),
+			slog.String("link", savedEvent.EventLink),
+			slog.String("canonicalID", canonical.ID.String()),
+			slog.String("canonicalLink", canonical.EventLink),
+			slog.Float64("score", score),
+		)
+	} else {
+		log.Debug("event created", slog.String("name", savedEvent.Name), slog.String("status", string(savedEvent.Status)))
+	}
+
+	if savedEvent.Status == domain.EventStatusNew {
+		s.sendToAI(log, savedEvent)
+	}
+
+	return savedEvent.ID, outcomeCreated
+}
+
+// attachVenue находит место проведения события в справочнике мест, сохраняет его
+// (или находит уже сохранённое) и строит по нему ссылку на карту вместо ссылки с сайта.
+// Снимок источника снимается до этого, поэтому добавление места в справочник не считается
+// изменением события на сайте.
+func (s *Scraper) attachVenue(ctx context.Context, log *slog.Logger, siteName string, event *domain.Event) {
+	if event.Venue.IsZero() {
+		return
+	}
+
+	venue, _ := s.gazetteer.Resolve(event.Venue)
+	venue.SiteName = siteName
+
+	venue, err := s.repository.FindOrCreateVenue(ctx, venue)
+	if err != nil {
+		log.Error("failed to find event venue", slog.String("error", err.Error()))
+		return
+	}
+
+	event.Venue = venue
+	event.VenueID = venue.ID
+	event.MapLink = venue.MapLink()
+}
+
+// attachImage скачивает фото события в локальное хранилище (или находит скачанное раньше
+// по той же ссылке) и запоминает его хеш. Если скачать фото не удалось, событие
+// публикуется со ссылкой на фото на сайте.
+func (s *Scraper) attachImage(ctx context.Context, log *slog.Logger, event *domain.Event) {
+	if s.images == nil || event.Photo == "" {
+		return
+	}
+
+	image, err := s.repository.FindImageBySourceURL(ctx, event.Photo)
+	if err != nil {
+		log.Error("failed to find event image", slog.String("error", err.Error()))
+	}
+	if image.Hash != "" {
+		event.ImageHash = image.Hash
+		return
+	}
+
+	image, err = s.images.Download(ctx, event.Photo, event.EventLink)
+	if err != nil {
+		log.Warn("failed to download event image", slog.String("photo", event.Photo), slog.String("error", err.Error()))
+		return
+	}
+	if err := s.repository.SaveImage(ctx, image); err != nil {
+		log.Error("failed to save event image", slog.String("error", err.Error()))
+		return
+	}
+
+	event.ImageHash = image.Hash
+}
+
+// findDuplicate ищет сохранённое ранее событие с другой ссылкой, описывающее то же мероприятие
+// (см. dedup.Matcher), и возвращает его вместе с оценкой сходства.
+func (s *Scraper) findDuplicate(ctx context.Context, log *slog.Logger, event domain.Event) (domain.Event, float64, bool) {
+	if !s.dedup.Enabled() || event.Date.IsZero() {
+		return domain.Event{}, 0, false
+	}
+
+	candidates, err := s.repository.FindDuplicateCandidates(ctx, event, s.dedup.Window())
+	if err != nil {
+		log.Error("failed to find duplicate candidates", slog.String("error", err.Error()))
+		return domain.Event{}, 0, false
+	}
+	return s.dedup.Best(event, candidates)
+}
+
+// findSeries возвращает серию, к которой относится событие (по ссылке на мероприятие),
+// создавая её для первой даты мероприятия.
+func (s *Scraper) findSeries(ctx context.Context, log *slog.Logger, siteName string, event domain.Event) (domain.EventSeries, bool) {
+	series := domain.NewEventSeries(event)
+	series.SiteName = siteName
+
+	series, err := s.repository.FindOrCreateSeries(ctx, series)
+	if err != nil {
+		log.Error("failed to find event series", slog.String("error", err.Error()))
+		return domain.EventSeries{}, false
+	}
+	return series, true
+}
+
+// findExisting ищет сохранённое ранее событие.
+// События без даты (например, из RSS) ищем только по ссылке: дату им позже проставляет AI.
+// Если по ссылке и дате ничего не найдено, а ссылка в выдаче одна, событие ищется только
+// по ссылке среди предстоящих — так находятся события, у которых на сайте сменилась дата или время.
+// Прошедшие, отклонённые и отменённые события так не находятся: для страницы, показывающей только
+// ближайшую дату мероприятия, новая дата сохраняется отдельным событием.
+func (s *Scraper) findExisting(ctx context.Context, event domain.Event, uniqueLink bool) (domain.Event, bool) {
+	if event.Date.IsZero() {
+		existing, err := s.repository.FindEventByLink(ctx, event.EventLink)
+		return existing, err == nil && existing.ID != uuid.Nil
+	}
+
+	existing, err := s.repository.FindEventByLinkAndDate(ctx, event.EventLink, event.Date)
+	if err == nil && existing.ID != uuid.Nil {
+		return existing, true
+	}
+
+	if !uniqueLink {
+		return domain.Event{}, false
+	}
+	candidates, err := s.repository.FindUpcomingEventsByLink(ctx, event.EventLink)
+	if err != nil || len(candidates) != 1 {
+		return domain.Event{}, false
+	}
+	return candidates[0], true
+}
+
+// updateExisting сравнивает отпечаток источника известного события с только что спарсенным
+// и при расхождении обновляет изменившиеся поля согласно политике сайта.
+func (s *Scraper) updateExisting(ctx context.Context, log *slog.Logger, siteName string, existing, scraped domain.Event, source domain.SourceSnapshot) eventOutcome {
+	log = log.With(slog.String("eventID", existing.ID.String()), slog.String("link", existing.EventLink))
+
+	// Отменённое скрапером событие снова нашлось на сайте — отмена была ложной
+	restored := false
+	if existing.Status == domain.EventStatusCancelled {
+		existing.Status, restored = s.restoreCancelled(ctx, log, existing)
+	}
+
+	if existing.Source.Fingerprint() == source.Fingerprint() {
+		if restored {
+			s.enrichRestored(log, existing)
+			return outcomeChanged
+		}
+		log.Debug("event already exists and is unchanged")
+		return outcomeUnchanged
+	}
+
+	// Событие сохранено до появления отпечатков: запоминаем текущее состояние источника,
+	// не считая его изменением
+	if len(existing.Source) == 0 {
+		existing.Source = source
+		existing.ChangedFields = nil
+		if _, err := s.repository.UpdateEventFromSource(ctx, existing); err != nil {
+			log.Error("failed to save event source", slog.String("error", err.Error()))
+		}
+		if restored {
+			s.enrichRestored(log, existing)
+			return outcomeChanged
+		}
+		return outcomeUnchanged
+	}
+
+	changed := source.Diff(existing.Source)
+	policy := s.changePolicy(siteName)
+
+	updated := existing
+	if policy == ChangePolicyEnrich && changedStatus(existing.Status, policy) == domain.EventStatusNew {
+		// AI обогащает событие заново, поэтому берём все поля из источника, а не только изменившиеся.
+		// Дату и цену, которые AI дописал сам, сохраняем, пока источник их не указал.
+		domain.ApplySourceFields(&updated, scraped, []string{
+			domain.SourceFieldName, domain.SourceFieldPhoto, domain.SourceFieldDescription,
+			domain.SourceFieldMapLink, domain.SourceFieldVideoURL,
+		})
+	}
+	domain.ApplySourceFields(&updated, scraped, changed)
+	if updated.Photo != existing.Photo {
+		updated.ImageHash = ""
+		s.attachImage(ctx, log, &updated)
+	}
+	updated.Source = source
+	updated.ChangedFields = changed
+	updated.Status = changedStatus(existing.Status, policy)
+
+	savedEvent, err := s.repository.UpdateEventFromSource(ctx, updated)
+	if err != nil {
+		log.Error("failed to update changed event", slog.String("error", err.Error()))
+		return outcomeFailed
+	}
+
+	log.Info("event changed in source",
+		slog.Any("changedFields", changed),
+		slog.String("policy", policy),
+		slog.String("status", string(savedEvent.Status)),
+	)
+
+	s.enrichChanged(ctx, log, policy, savedEvent)
+	if restored && policy != ChangePolicyEnrich {
+		s.enrichRestored(log, savedEvent)
+	}
+	return outcomeChanged
+}
+
+// restoreCancelled возвращает в работу отменённое событие, которое снова нашлось на сайте,
+// и возвращает его новый статус. Восстанавливаются только события, отменённые скрапером
+// (событие пропало из выдачи или страница временно отдала 404): отмену модератором скрапер
+// не трогает. Событие, отменённое до обработки AI, возвращается в NEW, остальные — на модерацию.
+// Счётчик пропусков сбрасывает MarkEventsSeen в конце скрапинга.
+func (s *Scraper) restoreCancelled(ctx context.Context, log *slog.Logger, event domain.Event) (domain.EventStatus, bool) {
+	history, err := s.repository.FindStatusHistory(ctx, event.ID)
+	if err != nil {
+		log.Error("failed to find event status history", slog.String("error", err.Error()))
+		return event.Status, false
+	}
+
+	var cancellation domain.StatusChange
+	for _, change := range slices.Backward(history) {
+		if change.To == domain.EventStatusCancelled {
+			cancellation = change
+			break
+		}
+	}
+	if cancellation.Actor.Type != domain.ActorScraper {
+		log.Warn("cancelled event found in source, leaving it cancelled", slog.String("cancelledBy", string(cancellation.Actor.Type)))
+		return event.Status, false
+	}
+
+	status := domain.EventStatusReadyToApprove
+	if cancellation.From == domain.EventStatusNew {
+		status = domain.EventStatusNew
+	}
+	if err := s.repository.UpdateEventStatus(ctx, event.ID, string(status), "событие снова появилось на сайте"); err != nil {
+		log.Error("failed to restore cancelled event", slog.String("error", err.Error()))
+		return event.Status, false
+	}
+
+	log.Info("cancelled event found in source again, restored",
+		slog.String("status", string(status)),
+		slog.String("cancelReason", cancellation.Reason),
+	)
+	return status, true
+}
+
+// enrichRestored отправляет в AI восстановленное событие, которое ещё не было обогащено.
+func (s *Scraper) enrichRestored(log *slog.Logger, event domain.Event) {
+	if event.Status == domain.EventStatusNew {
+		s.sendToAI(log, event)
+	}
+}
+
+// applyUpsertedChange применяет политику сайта к событию, изменившиеся поля которого уже обновил
+// CreateEvent (событие одновременно сохранил другой воркер): остаётся сменить статус и отправить в AI.
+func (s *Scraper) applyUpsertedChange(ctx context.Context, log *slog.Logger, siteName string, saved domain.Event) eventOutcome {
+	log = log.With(slog.String("eventID", saved.ID.String()), slog.String("link", saved.EventLink))
+
+	// Сохранён только снимок источника события, сохранённого до появления отпечатков
+	if len(saved.ChangedFields) == 0 {
+		return outcomeUnchanged
+	}
+
+	policy := s.changePolicy(siteName)
+	if status := changedStatus(saved.Status, policy); status != saved.Status {
+		if err := s.repository.UpdateEventStatus(ctx, saved.ID, string(status), "источник изменился"); err != nil {
+			log.Error("failed to update changed event status", slog.String("error", err.Error()))
+			return outcomeFailed
+		}
+		saved.Status = status
+	}
+
+	log.Info("event changed in source",
+		slog.Any("changedFields", saved.ChangedFields),
+		slog.String("policy", policy),
+		slog.String("status", string(saved.Status)),
+	)
+
+	s.enrichChanged(ctx, log, policy, saved)
+	return outcomeChanged
+}
+
+// enrichChanged отправляет изменившееся на сайте событие в AI, если этого требует политика сайта.
+func (s *Scraper) enrichChanged(ctx context.Context, log *slog.Logger, policy string, event domain.Event) {
+	if policy != ChangePolicyEnrich || event.Status != domain.EventStatusNew {
+		return
+	}
+
+	// Изменилось содержимое мероприятия — серию нужно обогатить заново.
+	// Если изменились только дата или цена, AI возьмёт содержимое из серии
+	if event.SeriesID != uuid.Nil && changesSeriesContent(event.ChangedFields) {
+		if err := s.repository.ResetSeriesEnrichment(ctx, event.SeriesID); err != nil {
+			log.Error("failed to reset series enrichment", slog.String("error", err.Error()))
+		}
+	}
+	s.sendToAI(log, event)
+}
+
+// changesSeriesContent проверяет, затрагивают ли изменившиеся поля источника общее содержимое серии.
+func changesSeriesContent(changed []string) bool {
+	for _, field := range changed {
+		switch field {
+		case domain.SourceFieldName, domain.SourceFieldDescription, domain.SourceFieldPhoto:
+			return true
+		}
+	}
+	return false
+}
+
+// trackMissingEvents отмечает найденные события как увиденные, а предстоящие события сайта,
+// которых в выдаче не оказалось, проверяет: если страница события удалена (404/410)
+// или событие не встречается CancelAfterMisses скрапингов подряд, оно отменяется.
+// Если выдача неполна (partial), пропуски не считаются: отменяются только события с удалёнными страницами.
+func (s *Scraper) trackMissingEvents(ctx context.Context, log *slog.Logger, siteName string, seen []uuid.UUID, partial bool) {
+	if err := s.repository.MarkEventsSeen(ctx, siteName, seen); err != nil {
+		log.Error("failed to mark events as seen", slog.String("error", err.Error()))
+		return
+	}
+
+	cancelAfter := s.siteConfigs[siteName].CancelAfterMisses
+	if cancelAfter < 0 {
+		return
+	}
+	if cancelAfter == 0 {
+		cancelAfter = defaultCancelAfterMisses
+	}
+
+	// Пустая выдача скорее означает поломку скрапера или сайта, чем отмену всех событий
+	if len(seen) == 0 {
+		log.Warn("scrape returned no events, skipping missing events check")
+		return
+	}
+
+	missing, err := s.repository.FindMissingEvents(ctx, siteName, seen)
+	if err != nil {
+		log.Error("failed to find missing events", slog.String("error", err.Error()))
+		return
+	}
+
+	for _, event := range missing {
+		eventlog := log.With(slog.String("eventID", event.ID.String()), slog.String("link", event.EventLink))
+
+		var reason string
+		if gone, err := s.fetcher.IsGone(ctx, event.EventLink); err != nil {
+			eventlog.Warn("failed to check event page", slog.String("error", err.Error()))
+		} else if gone {
+			reason = "страница события удалена с сайта"
+		}
+
+		if reason == "" {
+			if partial {
+				eventlog.Debug("event missing from partial scrape, not counting as missed")
+				continue
+			}
+			missed, err := s.repository.IncrementMissedScrapes(ctx, event.ID)
+			if err != nil {
+				eventlog.Error("failed to increment missed scrapes", slog.String("error", err.Error()))
+				continue
+			}
+			if missed < cancelAfter {
+				eventlog.Debug("event missing from source", slog.Int("missedScrapes", missed))
+				continue
+			}
+			reason = fmt.Sprintf("событие не найдено на сайте %d скрапингов подряд", missed)
+		}
+
+		if err := s.repository.UpdateEventStatus(ctx, event.ID, string(domain.EventStatusCancelled), reason); err != nil {
+			eventlog.Error("failed to cancel event", slog.String("error", err.Error()))
+			continue
+		}
+		eventlog.Info("event cancelled", slog.String("reason", reason), slog.String("previousStatus", string(event.Status)))
+
+		cancellation := domain.EventCancellation{Event: event, Reason: reason}
+		cancellation.Event.Status = domain.EventStatusCancelled
+		select {
+		case s.CancelledEventsChan <- cancellation:
+		default:
+			eventlog.Warn("CancelledEventsChan is full, skipping admin notification")
+		}
+	}
+}
+
+// knownChecker возвращает функцию, сообщающую скраперу, что страница события по ссылке
+// скачивалась недавно (в пределах SiteConfig.RefreshAfter) и её можно пропустить.
+// При отрицательном RefreshAfter страницы скачиваются всегда.
+func (s *Scraper) knownChecker(log *slog.Logger, siteName string) func(ctx context.Context, link string) bool {
+	refreshAfter := s.siteConfigs[siteName].RefreshAfter
+	if refreshAfter < 0 {
+		return nil
+	}
+	if refreshAfter == 0 {
+		refreshAfter = defaultRefreshAfter
+	}
+
+	return func(ctx context.Context, link string) bool {
+		known, err := s.repository.IsEventCheckedSince(ctx, link, time.Now().Add(-refreshAfter))
+		if err != nil {
+			log.Warn("failed to check known event", slog.String("link", link), slog.String("error", err.Error()))
+			return false
+		}
+		return known
+	}
+}
+
+// changePolicy возвращает политику обработки изменений для сайта.
+func (s *Scraper) changePolicy(siteName string) string {
+	if policy := s.siteConfigs[siteName].OnChange; policy != "" {
+		return policy
+	}
+	return ChangePolicyEnrich
+}
+
+// changedStatus возвращает статус события, изменившегося на сайте.
+// Отклонённые модератором события остаются отклонёнными, дубликаты — дубликатами.
+func changedStatus(current domain.EventStatus, policy string) domain.EventStatus {
+	if current == domain.EventStatusRejected || current == domain.EventStatusCancelled || current == domain.EventStatusDuplicate {
+		return current
+	}
+	switch policy {
+	case ChangePolicyEnrich:
+		return domain.EventStatusNew
+	case ChangePolicyModerate:
+		if current == domain.EventStatusNew {
+			return current
+		}
+		return domain.EventStatusReadyToApprove
+	default:
+		return current
+	}
+}
+
+// sendToAI отправляет событие в канал для обработки AI.
+func (s *Scraper) sendToAI(log *slog.Logger, event domain.Event) {
+	select {
+	case s.CompletedEventsChan <- event:
+	default:
+		log.Warn("CompletedEventsChan is full, skipping AI enrichment")
+	}
 }
 
 // Shutdown корректно завершает работу сервиса.
+// Выходные каналы закрываются только после завершения воркеров, чтобы
+// обработка текущей задачи не отправляла события в закрытый канал.
 func (s *Scraper) Shutdown(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
 		return fmt.Errorf("force exit scraper: %w", ctx.Err())
 	default:
 		close(s.shutdownChannel)
-		close(s.jobs)
-		close(s.CompletedEventsChan)
-		return nil
 	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("force exit scraper: %w", ctx.Err())
+	}
+
+	close(s.jobs)
+	close(s.CompletedEventsChan)
+	close(s.CancelledEventsChan)
+	close(s.HealthAlertsChan)
+	return nil
 }
@@ -0,0 +1,353 @@
+package sites
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+)
+
+const (
+	// defaultAPIMaxPages — ограничение числа страниц JSON API по умолчанию.
+	defaultAPIMaxPages = 20
+)
+
+// defaultAPIDateLayouts — форматы дат JSON API по умолчанию.
+var defaultAPIDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// apiScraper — источник событий из JSON REST API с настраиваемой пагинацией и маппингом полей.
+type apiScraper struct {
+	cfg      config.APIConfig
+	location *time.Location
+}
+
+// NewAPIScraper создаёт ScrapeFunc для JSON REST API по описанию из конфигурации.
+// Возвращает ошибку при неизвестном типе пагинации, некорректном часовом поясе
+// или если не задан путь к названию события.
+func NewAPIScraper(cfg config.APIConfig) (ScrapeFunc, error) {
+	if cfg.Fields.Name == "" {
+		return nil, fmt.Errorf("api scraper: fields.name path is empty")
+	}
+
+	switch cfg.Pagination.Type {
+	case "", "page", "cursor", "next":
+	default:
+		return nil, fmt.Errorf("api scraper: unknown pagination type: %s", cfg.Pagination.Type)
+	}
+	if cfg.Pagination.MaxPages <= 0 {
+		cfg.Pagination.MaxPages = defaultAPIMaxPages
+	}
+	if cfg.Pagination.StartPage == 0 {
+		cfg.Pagination.StartPage = 1
+	}
+	if len(cfg.DateLayouts) == 0 {
+		cfg.DateLayouts = defaultAPIDateLayouts
+	}
+
+	s := &apiScraper{cfg: cfg, location: time.UTC}
+
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("api scraper: invalid timezone: %w", err)
+		}
+		s.location = loc
+	}
+
+	return s.scrape, nil
+}
+
+// scrape обходит страницы API и возвращает собранные события.
+func (s *apiScraper) scrape(ctx context.Context, req Request) (Result, error) {
+	events, truncated, err := s.collect(ctx, req)
+	return Result{Events: events, Truncated: truncated}, err
+}
+
+// collect обходит страницы API согласно стратегии пагинации и преобразует элементы в события.
+// truncated сообщает, что обход остановлен ограничением Pagination.MaxPages.
+func (s *apiScraper) collect(ctx context.Context, req Request) (events []domain.Event, truncated bool, err error) {
+	template := s.cfg.URLTemplate
+	if template == "" {
+		template = req.URL
+	}
+
+	visited := make(map[string]bool)
+	page := s.cfg.Pagination.StartPage
+	cursor := ""
+	pageURL := s.pageURL(template, page, cursor)
+
+	for i := 0; i < s.cfg.Pagination.MaxPages && pageURL != "" && !visited[pageURL]; i++ {
+		if err := req.stopped(ctx); err != nil {
+			return events, false, err
+		}
+		visited[pageURL] = true
+
+		body, err := req.Fetcher.Fetch(ctx, pageURL, s.cfg.Headers)
+		if err != nil {
+			return events, false, err
+		}
+
+		var data any
+		if err := json.Unmarshal(body, &data); err != nil {
+			return events, false, fmt.Errorf("failed to decode %s: %w", pageURL, err)
+		}
+
+		items, err := s.items(data)
+		if err != nil {
+			return events, false, fmt.Errorf("%s: %w", pageURL, err)
+		}
+
+		base, _ := url.Parse(pageURL)
+		for _, item := range items {
+			if event, ok := s.toDomain(item, base); ok {
+				events = append(events, event)
+			}
+		}
+
+		// Следующая страница
+		switch s.cfg.Pagination.Type {
+		case "page":
+			if len(items) == 0 {
+				return events, false, nil
+			}
+			page++
+			pageURL = s.pageURL(template, page, cursor)
+		case "cursor":
+			cursor = jsonString(jsonPathFirst(data, s.cfg.Pagination.CursorPath))
+			if cursor == "" {
+				return events, false, nil
+			}
+			pageURL = s.pageURL(template, page, cursor)
+		case "next":
+			next := jsonString(jsonPathFirst(data, s.cfg.Pagination.NextPath))
+			if next == "" {
+				return events, false, nil
+			}
+			pageURL = resolveURL(base, next)
+		default:
+			return events, false, nil
+		}
+	}
+
+	return events, pageURL != "" && !visited[pageURL], nil
+}
+
+// pageURL подставляет номер страницы и курсор в шаблон URL.
+func (s *apiScraper) pageURL(template string, page int, cursor string) string {
+	return strings.NewReplacer(
+		"{page}", strconv.Itoa(page),
+		"{cursor}", url.QueryEscape(cursor),
+	).Replace(template)
+}
+
+// items возвращает массив событий из ответа API.
+func (s *apiScraper) items(data any) ([]any, error) {
+	value := data
+	if s.cfg.ItemsPath != "" {
+		value = jsonPathFirst(data, s.cfg.ItemsPath)
+	}
+	if value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("items path %q is not an array", s.cfg.ItemsPath)
+	}
+	return items, nil
+}
+
+// toDomain преобразует элемент ответа API в domain.Event по путям из конфигурации.
+// Возвращает false, если у элемента нет названия или ссылки/идентификатора.
+func (s *apiScraper) toDomain(item any, base *url.URL) (domain.Event, bool) {
+	fields := s.cfg.Fields
+
+	event := domain.Event{
+		Name:   jsonLDText(jsonPathFirst(item, fields.Name)),
+		Status: domain.EventStatusNew,
+	}
+	if event.Name == "" {
+		return event, false
+	}
+
+	if fields.Description != "" {
+		event.Description, _ = htmlToText(jsonString(jsonPathFirst(item, fields.Description)))
+	}
+
+	if fields.Date != "" {
+		date := jsonPathFirst(item, fields.Date)
+		event.Date = s.parseDate(date)
+		event.AllDay = !event.Date.IsZero() && isDateOnly(jsonString(date))
+	}
+	if fields.EndDate != "" {
+		event.EndDate = s.parseDate(jsonPathFirst(item, fields.EndDate))
+	}
+
+	if fields.Price != "" {
+		currency := s.cfg.Currency
+		if fields.Currency != "" {
+			if c := jsonString(jsonPathFirst(item, fields.Currency)); c != "" {
+				currency = c
+			}
+		}
+		// Максимальная цена разбирается вместе с минимальной как диапазон
+		priceText := jsonString(jsonPathFirst(item, fields.Price))
+		if fields.PriceMax != "" {
+			priceText += " " + jsonString(jsonPathFirst(item, fields.PriceMax))
+		}
+		if price, ok := parsePrice(priceText, nil, currency); ok {
+			event.Price = price
+		}
+	}
+	if fields.Free != "" && jsonBool(jsonPathFirst(item, fields.Free)) {
+		event.Price.Free = true
+		event.Price.Normalize()
+	}
+
+	if fields.Venue != "" {
+		switch venue := jsonPathFirst(item, fields.Venue).(type) {
+		case map[string]any:
+			event.Venue = jsonLDLocation(venue)
+		default:
+			event.Venue.Name = jsonLDText(venue)
+		}
+	}
+	if fields.Address != "" {
+		if address := jsonLDAddress(jsonPathFirst(item, fields.Address)); address != "" {
+			event.Venue.Address = address
+		}
+	}
+	if fields.Latitude != "" && fields.Longitude != "" {
+		lat, latErr := strconv.ParseFloat(jsonString(jsonPathFirst(item, fields.Latitude)), 64)
+		lon, lonErr := strconv.ParseFloat(jsonString(jsonPathFirst(item, fields.Longitude)), 64)
+		if latErr == nil && lonErr == nil {
+			event.Venue.Latitude, event.Venue.Longitude = lat, lon
+		}
+	}
+
+	if fields.Photo != "" {
+		if photo := jsonLDImage(jsonPathFirst(item, fields.Photo)); photo != "" {
+			event.Photo = resolveURL(base, photo)
+		}
+	}
+
+	if fields.Video != "" {
+		event.VideoURL = jsonString(jsonPathFirst(item, fields.Video))
+	}
+
+	if fields.Link != "" {
+		if link := jsonString(jsonPathFirst(item, fields.Link)); link != "" {
+			event.EventLink = resolveURL(base, link)
+		}
+	}
+	// Без ссылки событие идентифицируется адресом API и идентификатором
+	if event.EventLink == "" && fields.ID != "" && base != nil {
+		if id := jsonString(jsonPathFirst(item, fields.ID)); id != "" {
+			event.EventLink = base.Scheme + "://" + base.Host + base.Path + "#" + url.PathEscape(id)
+		}
+	}
+	if event.EventLink == "" {
+		return event, false
+	}
+
+	return event, true
+}
+
+// parseDate разбирает дату из строки по форматам конфигурации или из unix-времени
+// (в секундах или миллисекундах).
+func (s *apiScraper) parseDate(v any) time.Time {
+	if n, ok := v.(float64); ok {
+		if n > 1e12 {
+			return time.UnixMilli(int64(n)).In(s.location)
+		}
+		return time.Unix(int64(n), 0).In(s.location)
+	}
+	if t, ok := parseDate(jsonString(v), s.cfg.DateLayouts, s.location); ok {
+		return t
+	}
+	return time.Time{}
+}
+
+// jsonPathFirst возвращает значение по первому из альтернативных путей (разделённых "|"),
+// для которого значение найдено и не пусто.
+func jsonPathFirst(data any, paths string) any {
+	for _, path := range strings.Split(paths, "|") {
+		if value, ok := jsonPath(data, strings.TrimSpace(path)); ok && value != nil && value != "" {
+			return value
+		}
+	}
+	return nil
+}
+
+// jsonPath возвращает значение по пути вида "$.data.items[0].title".
+// Поддерживаются обращение к полям через точку и индексы массивов.
+func jsonPath(data any, path string) (any, bool) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return data, true
+	}
+
+	current := data
+	for _, segment := range strings.Split(path, ".") {
+		name, indexes, err := splitPathSegment(segment)
+		if err != nil {
+			return nil, false
+		}
+
+		if name != "" {
+			obj, ok := current.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if current, ok = obj[name]; !ok {
+				return nil, false
+			}
+		}
+
+		for _, index := range indexes {
+			arr, ok := current.([]any)
+			if !ok {
+				return nil, false
+			}
+			if index < 0 {
+				index += len(arr)
+			}
+			if index < 0 || index >= len(arr) {
+				return nil, false
+			}
+			current = arr[index]
+		}
+	}
+
+	return current, true
+}
+
+// splitPathSegment разбирает сегмент пути вида "items[0][1]" на имя поля и индексы.
+func splitPathSegment(segment string) (string, []int, error) {
+	name, rest, found := strings.Cut(segment, "[")
+	if !found {
+		return segment, nil, nil
+	}
+
+	var indexes []int
+	for _, part := range strings.Split("["+rest, "[")[1:] {
+		index, err := strconv.Atoi(strings.TrimSuffix(part, "]"))
+		if err != nil || !strings.HasSuffix(part, "]") {
+			return "", nil, fmt.Errorf("invalid path segment: %s", segment)
+		}
+		indexes = append(indexes, index)
+	}
+
+	return name, indexes, nil
+}
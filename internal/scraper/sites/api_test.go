@@ -0,0 +1,468 @@
+package sites
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+)
+
+// testFetchConfig — настройки загрузки для тестов: без пауз и повторов.
+var testFetchConfig = config.FetchConfig{
+	Concurrency: 4,
+	Retries:     -1,
+	RateLimit:   1000,
+}
+
+// apiServer — httptest-сервер JSON API, отдающий ответы по пути с параметрами запроса.
+type apiServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses map[string]string // Тела ответов по RequestURI
+	requested []string          // RequestURI запросов по порядку, кроме robots.txt
+}
+
+func newAPIServer(t *testing.T, responses map[string]string) *apiServer {
+	s := &apiServer{responses: responses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+
+		s.mu.Lock()
+		s.requested = append(s.requested, r.URL.RequestURI())
+		s.mu.Unlock()
+
+		body, ok := s.responses[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// scrapeAPI запускает API-скрапер с настройками cfg по адресу rawURL.
+func scrapeAPI(t *testing.T, cfg config.APIConfig, rawURL string) []domain.Event {
+	t.Helper()
+	return scrapeAPIResult(t, cfg, rawURL).Events
+}
+
+// scrapeAPIResult запускает API-скрапер и возвращает весь результат запуска.
+func scrapeAPIResult(t *testing.T, cfg config.APIConfig, rawURL string) Result {
+	t.Helper()
+
+	scrapeFunc, err := NewAPIScraper(cfg)
+	if err != nil {
+		t.Fatalf("NewAPIScraper: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := scrapeFunc(ctx, Request{
+		URL:      rawURL,
+		Shutdown: make(chan struct{}),
+		Fetcher:  NewFetcher(testFetchConfig),
+	})
+	if err != nil {
+		t.Fatalf("scrape: %v", err)
+	}
+	return result
+}
+
+func eventNames(events []domain.Event) []string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = e.Name
+	}
+	return names
+}
+
+func TestAPIScraperPagePagination(t *testing.T) {
+	server := newAPIServer(t, map[string]string{
+		"/api/events?page=1": `{"data": [{"id": 1, "title": "First"}, {"id": 2, "title": "Second"}]}`,
+		"/api/events?page=2": `{"data": [{"id": 3, "title": "Third"}]}`,
+		"/api/events?page=3": `{"data": []}`,
+		"/api/events?page=4": `{"data": [{"id": 4, "title": "Never requested"}]}`,
+	})
+
+	result := scrapeAPIResult(t, config.APIConfig{
+		URLTemplate: server.URL + "/api/events?page={page}",
+		ItemsPath:   "$.data",
+		Pagination:  config.APIPaginationConfig{Type: "page"},
+		Fields:      config.APIFieldsConfig{ID: "id", Name: "title"},
+	}, server.URL)
+	events := result.Events
+	if result.Truncated {
+		t.Error("truncated = true, want false")
+	}
+
+	if got, want := eventNames(events), []string{"First", "Second", "Third"}; !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	// Пустая страница останавливает обход
+	if want := []string{"/api/events?page=1", "/api/events?page=2", "/api/events?page=3"}; !slices.Equal(server.requested, want) {
+		t.Errorf("requested = %v, want %v", server.requested, want)
+	}
+	if got, want := events[2].EventLink, server.URL+"/api/events#3"; got != want {
+		t.Errorf("event link from id = %q, want %q", got, want)
+	}
+}
+
+func TestAPIScraperPagePaginationMaxPages(t *testing.T) {
+	responses := make(map[string]string)
+	for page := 0; page < 10; page++ {
+		responses[fmt.Sprintf("/events?p=%d", page)] = fmt.Sprintf(`[{"title": "Event %d", "url": "/e/%d"}]`, page, page)
+	}
+	server := newAPIServer(t, responses)
+
+	result := scrapeAPIResult(t, config.APIConfig{
+		URLTemplate: server.URL + "/events?p={page}",
+		Pagination:  config.APIPaginationConfig{Type: "page", StartPage: 2, MaxPages: 3},
+		Fields:      config.APIFieldsConfig{Name: "title", Link: "url"},
+	}, server.URL)
+
+	if got, want := eventNames(result.Events), []string{"Event 2", "Event 3", "Event 4"}; !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if len(server.requested) != 3 {
+		t.Errorf("requested = %v, want 3 pages", server.requested)
+	}
+	// Обход остановлен ограничением, а не концом выдачи
+	if !result.Truncated {
+		t.Error("truncated = false, want true")
+	}
+}
+
+func TestAPIScraperCursorPagination(t *testing.T) {
+	server := newAPIServer(t, map[string]string{
+		"/v2/events?cursor=":          `{"items": [{"name": "A", "link": "https://example.com/a"}], "meta": {"next": "abc=="}}`,
+		"/v2/events?cursor=abc%3D%3D": `{"items": [{"name": "B", "link": "https://example.com/b"}], "meta": {"next": "def"}}`,
+		"/v2/events?cursor=def":       `{"items": [{"name": "C", "link": "https://example.com/c"}], "meta": {"next": null}}`,
+	})
+
+	events := scrapeAPI(t, config.APIConfig{
+		URLTemplate: server.URL + "/v2/events?cursor={cursor}",
+		ItemsPath:   "items",
+		Pagination:  config.APIPaginationConfig{Type: "cursor", CursorPath: "$.meta.next"},
+		Fields:      config.APIFieldsConfig{Name: "name", Link: "link"},
+	}, server.URL)
+
+	if got, want := eventNames(events), []string{"A", "B", "C"}; !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if want := []string{"/v2/events?cursor=", "/v2/events?cursor=abc%3D%3D", "/v2/events?cursor=def"}; !slices.Equal(server.requested, want) {
+		t.Errorf("requested = %v, want %v", server.requested, want)
+	}
+}
+
+// tribeEventsPage — страница ответа WordPress-плагина The Events Calendar (/wp-json/tribe/events/v1/events).
+const tribeEventsPage = `{
+  "events": [
+    {
+      "id": 1042,
+      "title": "Jazz &amp; Wine Night",
+      "description": "<p>Live jazz with <strong>local wines</strong>.</p>",
+      "url": "https://venue.example/event/jazz-wine-night/",
+      "all_day": false,
+      "start_date": "2025-04-12 20:00:00",
+      "end_date": "2025-04-12 23:00:00",
+      "cost": "€10 – €25",
+      "cost_details": {"currency_symbol": "€", "currency_code": "EUR", "values": ["10", "25"]},
+      "image": {"url": "https://venue.example/wp-content/uploads/jazz.jpg", "width": 1200},
+      "venue": {"id": 7, "venue": "Sala Matisse", "address": "Carrer de Ramon Llull 1", "city": "Valencia", "geo_lat": 39.4702, "geo_lng": -0.3535}
+    },
+    {
+      "id": 1043,
+      "title": "Open Air Cinema",
+      "description": "Free screening in the park.",
+      "url": "https://venue.example/event/open-air-cinema/",
+      "all_day": true,
+      "start_date": "2025-04-13",
+      "end_date": "2025-04-13",
+      "cost": "Free",
+      "image": false,
+      "venue": []
+    }
+  ],
+  "total": 3,
+  "total_pages": 2,
+  "next_rest_url": "%s/wp-json/tribe/events/v1/events?page=2"
+}`
+
+func TestAPIScraperNextLinkPaginationTribeEvents(t *testing.T) {
+	server := newAPIServer(t, nil)
+	server.responses = map[string]string{
+		"/wp-json/tribe/events/v1/events": fmt.Sprintf(tribeEventsPage, server.URL),
+		"/wp-json/tribe/events/v1/events?page=2": `{
+		  "events": [{"id": 1050, "title": "Poetry Slam", "url": "/event/poetry-slam/", "start_date": "2025-04-20 19:30:00", "cost": "5"}],
+		  "total": 3, "total_pages": 2
+		}`,
+	}
+
+	events := scrapeAPI(t, config.APIConfig{
+		ItemsPath:  "events",
+		Pagination: config.APIPaginationConfig{Type: "next", NextPath: "next_rest_url"},
+		Fields: config.APIFieldsConfig{
+			ID:          "id",
+			Name:        "title",
+			Description: "description",
+			Date:        "start_date",
+			EndDate:     "end_date",
+			Price:       "cost_details.values[0]|cost",
+			PriceMax:    "cost_details.values[-1]",
+			Currency:    "cost_details.currency_code",
+			Photo:       "image",
+			Link:        "url",
+			Venue:       "venue.venue",
+			Address:     "venue.address",
+			Latitude:    "venue.geo_lat",
+			Longitude:   "venue.geo_lng",
+		},
+		Timezone: "Europe/Madrid",
+		Currency: "EUR",
+	}, server.URL+"/wp-json/tribe/events/v1/events")
+
+	if got, want := eventNames(events), []string{"Jazz & Wine Night", "Open Air Cinema", "Poetry Slam"}; !slices.Equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	jazz := events[0]
+	if want := time.Date(2025, 4, 12, 20, 0, 0, 0, madrid); !jazz.Date.Equal(want) || jazz.AllDay {
+		t.Errorf("jazz date = %v (all day %v), want %v", jazz.Date, jazz.AllDay, want)
+	}
+	if want := time.Date(2025, 4, 12, 23, 0, 0, 0, madrid); !jazz.EndDate.Equal(want) {
+		t.Errorf("jazz end date = %v, want %v", jazz.EndDate, want)
+	}
+	if want := (domain.Price{Min: 10, Max: 25, Currency: "EUR"}); jazz.Price != want {
+		t.Errorf("jazz price = %+v, want %+v", jazz.Price, want)
+	}
+	if jazz.Description != "Live jazz with local wines." {
+		t.Errorf("jazz description = %q", jazz.Description)
+	}
+	if jazz.Photo != "https://venue.example/wp-content/uploads/jazz.jpg" {
+		t.Errorf("jazz photo = %q", jazz.Photo)
+	}
+	if want := (domain.Venue{Name: "Sala Matisse", Address: "Carrer de Ramon Llull 1", Latitude: 39.4702, Longitude: -0.3535}); jazz.Venue != want {
+		t.Errorf("jazz venue = %+v, want %+v", jazz.Venue, want)
+	}
+
+	cinema := events[1]
+	if !cinema.AllDay || !cinema.Price.Free || cinema.Photo != "" {
+		t.Errorf("cinema = all day %v, price %+v, photo %q; want all-day free event without photo", cinema.AllDay, cinema.Price, cinema.Photo)
+	}
+
+	// Ссылка на второй странице относительна адреса страницы API
+	if want := server.URL + "/event/poetry-slam/"; events[2].EventLink != want {
+		t.Errorf("poetry slam link = %q, want %q", events[2].EventLink, want)
+	}
+	// На последней странице нет next_rest_url — обход останавливается
+	if len(server.requested) != 2 {
+		t.Errorf("requested = %v, want 2 pages", server.requested)
+	}
+}
+
+func TestAPIScraperNextLinkLoop(t *testing.T) {
+	server := newAPIServer(t, map[string]string{
+		"/events":        `{"items": [{"title": "A", "url": "/a"}], "next": "/events?page=2"}`,
+		"/events?page=2": `{"items": [{"title": "B", "url": "/b"}], "next": "/events"}`,
+	})
+
+	events := scrapeAPI(t, config.APIConfig{
+		ItemsPath:  "items",
+		Pagination: config.APIPaginationConfig{Type: "next", NextPath: "next"},
+		Fields:     config.APIFieldsConfig{Name: "title", Link: "url"},
+	}, server.URL+"/events")
+
+	// Уже пройденная страница не запрашивается повторно
+	if got, want := eventNames(events), []string{"A", "B"}; !slices.Equal(got, want) || len(server.requested) != 2 {
+		t.Errorf("events = %v (requested %v), want %v in 2 requests", got, server.requested, want)
+	}
+}
+
+func TestAPIScraperFieldMapping(t *testing.T) {
+	base := mustParseURL(t, "https://api.example.com/v1/events?page=1")
+	fields := config.APIFieldsConfig{
+		ID:          "id",
+		Name:        "title|name.es",
+		Description: "body",
+		Date:        "dates.start",
+		EndDate:     "dates.end",
+		Price:       "price.min",
+		PriceMax:    "price.max",
+		Free:        "free",
+		Currency:    "price.currency",
+		Photo:       "images[0]",
+		Link:        "links.self",
+		Video:       "media.video",
+		Venue:       "place",
+		Address:     "place.address",
+	}
+
+	tests := []struct {
+		name string
+		item string
+		want domain.Event
+		ok   bool
+	}{
+		{
+			name: "alternative name path and nested fields",
+			item: `{"id": "x1", "name": {"es": "Concierto"}, "dates": {"start": "2025-05-01T21:00:00+02:00"},
+				"price": {"min": 12, "max": 20, "currency": "USD"}, "images": [{"url": "/img/x1.jpg"}, "/img/other.jpg"],
+				"links": {"self": "/events/x1"}, "media": {"video": "https://youtu.be/abc"},
+				"place": {"name": "Teatro", "address": {"streetAddress": "Calle 1", "addressLocality": "Valencia"}}}`,
+			want: domain.Event{
+				Name:      "Concierto",
+				Date:      time.Date(2025, 5, 1, 19, 0, 0, 0, time.UTC),
+				Price:     domain.Price{Min: 12, Max: 20, Currency: "USD"},
+				Photo:     "https://api.example.com/img/x1.jpg",
+				EventLink: "https://api.example.com/events/x1",
+				VideoURL:  "https://youtu.be/abc",
+				Venue:     domain.Venue{Name: "Teatro", Address: "Calle 1, Valencia"},
+				Status:    domain.EventStatusNew,
+			},
+			ok: true,
+		},
+		{
+			name: "unix timestamps, free flag and link from id",
+			item: `{"id": 77, "title": "Mercado", "body": "<p>Cada domingo</p>", "dates": {"start": 1746900000, "end": 1746921600000},
+				"free": true, "place": "Plaza Mayor"}`,
+			want: domain.Event{
+				Name:        "Mercado",
+				Description: "Cada domingo",
+				Date:        time.Unix(1746900000, 0),
+				EndDate:     time.UnixMilli(1746921600000),
+				Price:       domain.Price{Free: true},
+				EventLink:   "https://api.example.com/v1/events#77",
+				Venue:       domain.Venue{Name: "Plaza Mayor"},
+				Status:      domain.EventStatusNew,
+			},
+			ok: true,
+		},
+		{
+			name: "date only is all day",
+			item: `{"title": "Feria", "dates": {"start": "2025-06-10"}, "links": {"self": "https://feria.example/"}}`,
+			want: domain.Event{
+				Name:      "Feria",
+				Date:      time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC),
+				AllDay:    true,
+				EventLink: "https://feria.example/",
+				Status:    domain.EventStatusNew,
+			},
+			ok: true,
+		},
+		{
+			name: "no name",
+			item: `{"id": "x2", "title": "", "links": {"self": "/events/x2"}}`,
+			ok:   false,
+		},
+		{
+			name: "no link and no id",
+			item: `{"title": "Orphan"}`,
+			ok:   false,
+		},
+	}
+
+	s := &apiScraper{cfg: config.APIConfig{Fields: fields, DateLayouts: defaultAPIDateLayouts, Currency: "EUR"}, location: time.UTC}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := s.toDomain(mustDecodeJSON(t, tt.item), base)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v (event %+v)", ok, tt.ok, event)
+			}
+			if !ok {
+				return
+			}
+			if !event.Date.Equal(tt.want.Date) || !event.EndDate.Equal(tt.want.EndDate) {
+				t.Errorf("dates = %v – %v, want %v – %v", event.Date, event.EndDate, tt.want.Date, tt.want.EndDate)
+			}
+			event.Date, event.EndDate = tt.want.Date, tt.want.EndDate
+			if fmt.Sprintf("%+v", event) != fmt.Sprintf("%+v", tt.want) {
+				t.Errorf("event =\n%+v\nwant\n%+v", event, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	data := mustDecodeJSON(t, `{"a": {"b": [10, {"c": "deep"}, [1, 2]]}, "empty": "", "zero": 0}`)
+
+	tests := []struct {
+		path string
+		want any
+		ok   bool
+	}{
+		{"$", data, true},
+		{"$.a.b[0]", float64(10), true},
+		{"a.b[1].c", "deep", true},
+		{"a.b[-1][1]", float64(2), true},
+		{"a.b[3]", nil, false},
+		{"a.missing", nil, false},
+		{"a.b.c", nil, false},
+		{"a.b[x]", nil, false},
+		{"zero", float64(0), true},
+	}
+
+	for _, tt := range tests {
+		got, ok := jsonPath(data, tt.path)
+		if ok != tt.ok || (tt.path != "$" && fmt.Sprint(got) != fmt.Sprint(tt.want)) {
+			t.Errorf("jsonPath(%q) = %v, %v; want %v, %v", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// Пустые значения пропускаются в пользу следующего пути
+	if got := jsonPathFirst(data, "empty | missing | a.b[1].c"); got != "deep" {
+		t.Errorf("jsonPathFirst = %v, want deep", got)
+	}
+}
+
+func TestNewAPIScraperValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.APIConfig
+	}{
+		{"no name path", config.APIConfig{}},
+		{"unknown pagination", config.APIConfig{Fields: config.APIFieldsConfig{Name: "title"}, Pagination: config.APIPaginationConfig{Type: "offset"}}},
+		{"invalid timezone", config.APIConfig{Fields: config.APIFieldsConfig{Name: "title"}, Timezone: "Mars/Olympus"}},
+	}
+	for _, tt := range tests {
+		if _, err := NewAPIScraper(tt.cfg); err == nil {
+			t.Errorf("%s: NewAPIScraper() error = nil", tt.name)
+		}
+	}
+}
+
+func mustParseURL(t *testing.T, rawURL string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func mustDecodeJSON(t *testing.T, data string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
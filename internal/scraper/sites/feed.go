@@ -0,0 +1,236 @@
+package sites
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// feedDocument — общее представление RSS 2.0, RSS 1.0 (RDF) и Atom.
+type feedDocument struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Items   []rssItem   `xml:"item"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// rssItem — запись RSS-ленты.
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	Content     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Enclosures  []struct {
+		URL  string `xml:"url,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"enclosure"`
+	Media []struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Medium string `xml:"medium,attr"`
+	} `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails []struct {
+		URL string `xml:"url,attr"`
+	} `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// atomEntry — запись Atom-ленты.
+type atomEntry struct {
+	Title string `xml:"title"`
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"link"`
+	Summary string `xml:"summary"`
+	Content string `xml:"content"`
+}
+
+// feedItem — запись ленты независимо от формата.
+type feedItem struct {
+	Title       string
+	Link        string
+	Description string // HTML или текст
+	Photo       string
+}
+
+// feedScraper — источник анонсов из RSS/Atom-ленты.
+type feedScraper struct {
+	cfg config.FeedConfig
+}
+
+// NewFeedScraper создаёт ScrapeFunc для RSS/Atom-ленты.
+// Даты и цены в лентах обычно отсутствуют — их определяет AI на этапе обогащения.
+func NewFeedScraper(cfg config.FeedConfig) (ScrapeFunc, error) {
+	return (&feedScraper{cfg: cfg}).scrape, nil
+}
+
+// scrape скачивает ленту и превращает её записи в события со статусом NEW.
+// Если задан contentSelector, полное описание берётся со страницы записи;
+// страницы известных событий при этом не скачиваются.
+func (s *feedScraper) scrape(ctx context.Context, req Request) (Result, error) {
+	if err := req.stopped(ctx); err != nil {
+		return Result{}, err
+	}
+
+	feedURL := req.URL
+	body, err := req.Fetcher.Fetch(ctx, feedURL, nil)
+	if err != nil {
+		return Result{}, err
+	}
+
+	items, err := parseFeed(body)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to parse feed %s: %w", feedURL, err)
+	}
+
+	if s.cfg.MaxItems > 0 && len(items) > s.cfg.MaxItems {
+		items = items[:s.cfg.MaxItems]
+	}
+
+	byLink := make(map[string]domain.Event, len(items))
+	var links []string
+	for _, item := range items {
+		if item.Title == "" || item.Link == "" {
+			continue
+		}
+		if _, dup := byLink[item.Link]; dup {
+			continue
+		}
+
+		description, photo := htmlToText(item.Description)
+		if item.Photo == "" {
+			item.Photo = photo
+		}
+
+		byLink[item.Link] = domain.Event{
+			Name:        strings.TrimSpace(item.Title),
+			Description: description,
+			Photo:       item.Photo,
+			EventLink:   item.Link,
+			Status:      domain.EventStatusNew,
+		}
+		links = append(links, item.Link)
+	}
+
+	if s.cfg.ContentSelector == "" {
+		events := make([]domain.Event, 0, len(links))
+		for _, link := range links {
+			events = append(events, byLink[link])
+		}
+		return Result{Events: events}, nil
+	}
+
+	fetched, res, err := fetchPages(ctx, req, links, func(link string, doc *goquery.Document, _ *url.URL) (domain.Event, bool) {
+		event := byLink[link]
+		if full := s.content(doc); full != "" {
+			event.Description = full
+		}
+		return event, true
+	})
+	if err != nil {
+		res.Events = fetched
+		return res, err
+	}
+
+	// Записи, страницы которых не удалось или запрещено скачивать, остаются с описанием из ленты
+	for _, event := range fetched {
+		byLink[event.EventLink] = event
+	}
+	for _, link := range res.Skipped {
+		delete(byLink, link)
+	}
+	res.Events = make([]domain.Event, 0, len(byLink))
+	for _, link := range links {
+		if event, ok := byLink[link]; ok {
+			res.Events = append(res.Events, event)
+		}
+	}
+
+	return res, nil
+}
+
+// content возвращает текст блока contentSelector со страницы записи.
+func (s *feedScraper) content(doc *goquery.Document) string {
+	content := doc.Find(s.cfg.ContentSelector).First().Clone()
+	content.Find("script, style").Remove()
+
+	return strings.TrimSpace(content.Text())
+}
+
+// parseFeed разбирает RSS 2.0, RSS 1.0 или Atom и возвращает записи ленты.
+func parseFeed(data []byte) ([]feedItem, error) {
+	var doc feedDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var items []feedItem
+	for _, item := range append(doc.Channel.Items, doc.Items...) {
+		fi := feedItem{
+			Title:       item.Title,
+			Link:        strings.TrimSpace(item.Link),
+			Description: item.Description,
+		}
+		if item.Content != "" {
+			fi.Description = item.Content
+		}
+		for _, enc := range item.Enclosures {
+			if strings.HasPrefix(enc.Type, "image/") {
+				fi.Photo = enc.URL
+				break
+			}
+		}
+		for _, media := range item.Media {
+			if fi.Photo == "" && (media.Medium == "image" || strings.HasPrefix(media.Type, "image/")) {
+				fi.Photo = media.URL
+			}
+		}
+		if fi.Photo == "" && len(item.Thumbnails) > 0 {
+			fi.Photo = item.Thumbnails[0].URL
+		}
+		items = append(items, fi)
+	}
+
+	for _, entry := range doc.Entries {
+		fi := feedItem{
+			Title:       entry.Title,
+			Description: entry.Summary,
+		}
+		if entry.Content != "" {
+			fi.Description = entry.Content
+		}
+		for _, link := range entry.Links {
+			switch {
+			case (link.Rel == "" || link.Rel == "alternate") && fi.Link == "":
+				fi.Link = strings.TrimSpace(link.Href)
+			case link.Rel == "enclosure" && strings.HasPrefix(link.Type, "image/") && fi.Photo == "":
+				fi.Photo = link.Href
+			}
+		}
+		items = append(items, fi)
+	}
+
+	return items, nil
+}
+
+// htmlToText превращает HTML-описание в текст и возвращает первую картинку из него.
+func htmlToText(s string) (string, string) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(s))
+	if err != nil {
+		return strings.TrimSpace(s), ""
+	}
+	doc.Find("script, style").Remove()
+
+	photo, _ := doc.Find("img").First().Attr("src")
+
+	return strings.TrimSpace(doc.Text()), photo
+}
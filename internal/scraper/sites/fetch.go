@@ -0,0 +1,438 @@
+package sites
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"eventsBot/internal/config"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	// maxBodySize ограничивает размер скачиваемого документа, если вызывающий не задал свой предел.
+	maxBodySize int64 = 10 << 20
+	// maxBackoff ограничивает паузу между повторами запроса.
+	maxBackoff = time.Minute
+)
+
+// Значения по умолчанию для незаданных настроек FetchConfig.
+const (
+	defaultFetchConcurrency   = 4
+	defaultFetchTimeout       = 30 * time.Second
+	defaultFetchRetries       = 3
+	defaultFetchBackoff       = time.Second
+	defaultFetchIdleConnsHost = 8
+)
+
+// StatusError — ответ сервера с кодом, отличным от 2xx.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("failed to fetch %s: unexpected status %d", e.URL, e.StatusCode)
+}
+
+// ErrBodyTooLarge — тело ответа больше допустимого размера. Такой документ не обрезается,
+// а не скачивается: разбор обрезанной страницы или фото дал бы неверный результат.
+var ErrBodyTooLarge = errors.New("response body is too large")
+
+// Fetcher — общий слой загрузки страниц для скраперов: один HTTP-клиент на все сайты,
+// ограничение числа одновременных запросов к домену, таймаут на запрос и повторы с backoff.
+type Fetcher struct {
+	client      *http.Client
+	concurrency int
+	timeout     time.Duration
+	retries     int
+	backoff     time.Duration
+	polite      *politeness
+
+	mu      sync.Mutex
+	domains map[string]chan struct{} // Семафоры одновременных запросов по доменам
+}
+
+// NewFetcher создаёт Fetcher по настройкам из конфигурации.
+func NewFetcher(cfg config.FetchConfig) *Fetcher {
+	return NewFetcherWithTransport(cfg, nil)
+}
+
+// NewFetcherWithTransport создаёт Fetcher, выполняющий запросы через transport
+// (например, для записи и воспроизведения страниц). Если transport — nil,
+// используется копия http.DefaultTransport.
+func NewFetcherWithTransport(cfg config.FetchConfig, transport http.RoundTripper) *Fetcher {
+	f := &Fetcher{
+		concurrency: cfg.Concurrency,
+		timeout:     cfg.RequestTimeout,
+		retries:     cfg.Retries,
+		backoff:     cfg.Backoff,
+		domains:     make(map[string]chan struct{}),
+	}
+	if f.concurrency <= 0 {
+		f.concurrency = defaultFetchConcurrency
+	}
+	if f.timeout <= 0 {
+		f.timeout = defaultFetchTimeout
+	}
+	if f.retries < 0 {
+		f.retries = 0
+	} else if f.retries == 0 {
+		f.retries = defaultFetchRetries
+	}
+	if f.backoff <= 0 {
+		f.backoff = defaultFetchBackoff
+	}
+
+	f.polite = newPoliteness(cfg, f.timeout)
+
+	if transport == nil {
+		defaultTransport := http.DefaultTransport.(*http.Transport).Clone()
+		defaultTransport.MaxIdleConnsPerHost = max(f.concurrency, defaultFetchIdleConnsHost)
+		transport = defaultTransport
+	}
+	f.client = &http.Client{Transport: transport}
+
+	return f
+}
+
+// SetSiteLimits задаёт ограничения частоты запросов к домену сайта siteURL:
+// число запросов в секунду и минимальную паузу между запросами.
+// Нулевой rateLimit заменяется значением по умолчанию из FetchConfig.
+func (f *Fetcher) SetSiteLimits(siteURL string, rateLimit float64, crawlDelay time.Duration) error {
+	u, err := url.Parse(siteURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid site url %q", siteURL)
+	}
+
+	f.polite.setLimits(u.Host, domainLimits{rateLimit: rateLimit, crawlDelay: crawlDelay})
+	return nil
+}
+
+// Concurrency возвращает допустимое число одновременных запросов к одному домену.
+func (f *Fetcher) Concurrency() int {
+	return f.concurrency
+}
+
+// Fetch скачивает документ по rawURL и возвращает его содержимое.
+// Сетевые ошибки, ответы 429 и 5xx повторяются с экспоненциальной паузой;
+// остальные ответы с кодом, отличным от 2xx, возвращаются как *StatusError,
+// а запрещённые robots.txt адреса — как *BlockedError. Документы больше 10 МБ
+// не скачиваются (ErrBodyTooLarge).
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string, headers map[string]string) ([]byte, error) {
+	return f.FetchLimit(ctx, rawURL, headers, maxBodySize)
+}
+
+// FetchLimit скачивает документ, как Fetch, но с пределом размера limit байт.
+func (f *Fetcher) FetchLimit(ctx context.Context, rawURL string, headers map[string]string, limit int64) ([]byte, error) {
+	body, _, err := f.fetch(ctx, rawURL, headers, limit)
+	return body, err
+}
+
+// FetchDocument скачивает HTML-страницу и возвращает документ и итоговый URL
+// (после редиректов) для разрешения относительных ссылок.
+func (f *Fetcher) FetchDocument(ctx context.Context, rawURL string) (*goquery.Document, *url.URL, error) {
+	body, finalURL, err := f.fetch(ctx, rawURL, nil, maxBodySize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", rawURL, err)
+	}
+
+	return doc, finalURL, nil
+}
+
+// IsGone проверяет, что страница события удалена с сайта (ответ 404 или 410).
+// Сначала выполняется HEAD-запрос, а если сервер его не поддерживает — GET.
+func (f *Fetcher) IsGone(ctx context.Context, rawURL string) (bool, error) {
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		resp, err := f.do(ctx, method, rawURL, nil)
+		if err != nil {
+			return false, err
+		}
+		resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusNotFound, http.StatusGone:
+			return true, nil
+		case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			continue
+		default:
+			return false, nil
+		}
+	}
+	return false, nil
+}
+
+// fetch выполняет GET с повторами и возвращает тело ответа не больше limit байт и итоговый URL.
+func (f *Fetcher) fetch(ctx context.Context, rawURL string, headers map[string]string, limit int64) ([]byte, *url.URL, error) {
+	var lastErr *retryError
+
+	for attempt := 0; attempt <= f.retries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, f.retryDelay(attempt, lastErr)); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		body, finalURL, retryAfter, err := f.fetchOnce(ctx, rawURL, headers, limit)
+		if err == nil {
+			return body, finalURL, nil
+		}
+		if !isRetryable(err) || ctx.Err() != nil {
+			return nil, nil, err
+		}
+		lastErr = &retryError{err: err, retryAfter: retryAfter}
+	}
+
+	return nil, nil, fmt.Errorf("giving up after %d attempts: %w", f.retries+1, lastErr.err)
+}
+
+// fetchOnce выполняет одну попытку GET-запроса с таймаутом на запрос.
+// При ответе с ошибкой также возвращает паузу из заголовка Retry-After.
+func (f *Fetcher) fetchOnce(ctx context.Context, rawURL string, headers map[string]string, limit int64) ([]byte, *url.URL, time.Duration, error) {
+	resp, err := f.do(ctx, http.MethodGet, rawURL, headers)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, parseRetryAfter(resp.Header.Get("Retry-After")), &StatusError{URL: rawURL, StatusCode: resp.StatusCode}
+	}
+
+	// Читаем на байт больше предела, чтобы отличить документ ровно в limit байт от более длинного
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to read %s: %w", rawURL, err)
+	}
+	if int64(len(body)) > limit {
+		return nil, nil, 0, fmt.Errorf("%s: %w: more than %d bytes", rawURL, ErrBodyTooLarge, limit)
+	}
+
+	return body, resp.Request.URL, 0, nil
+}
+
+// do выполняет запрос с соблюдением robots.txt и ограничения частоты запросов, заняв слот домена.
+// Слот и таймаут запроса освобождаются при закрытии тела ответа.
+func (f *Fetcher) do(ctx context.Context, method string, rawURL string, headers map[string]string) (*http.Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %s: %w", rawURL, err)
+	}
+
+	if err := f.polite.allow(ctx, f.client, u); err != nil {
+		return nil, err
+	}
+
+	release, err := f.acquire(ctx, u.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, f.timeout)
+	done := func() {
+		cancel()
+		release()
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, method, rawURL, nil)
+	if err != nil {
+		done()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	f.polite.setHeaders(req)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		done()
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: done}
+	return resp, nil
+}
+
+// acquire занимает слот домена host и возвращает функцию его освобождения.
+func (f *Fetcher) acquire(ctx context.Context, host string) (func(), error) {
+	f.mu.Lock()
+	sem, ok := f.domains[host]
+	if !ok {
+		sem = make(chan struct{}, f.concurrency)
+		f.domains[host] = sem
+	}
+	f.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		var once sync.Once
+		return func() { once.Do(func() { <-sem }) }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// retryDelay возвращает паузу перед попыткой attempt: Retry-After сервера,
+// если он задан, иначе экспоненциальный backoff со случайным разбросом.
+func (f *Fetcher) retryDelay(attempt int, lastErr *retryError) time.Duration {
+	if lastErr != nil && lastErr.retryAfter > 0 {
+		return min(lastErr.retryAfter, maxBackoff)
+	}
+
+	delay := min(f.backoff<<(attempt-1), maxBackoff)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// retryError — ошибка попытки запроса вместе с паузой, запрошенной сервером.
+type retryError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+// isRetryable определяет, имеет ли смысл повторить запрос.
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	var blockedErr *BlockedError
+	if errors.As(err, &blockedErr) || errors.Is(err, ErrBodyTooLarge) {
+		return false
+	}
+	return !errors.Is(err, context.Canceled)
+}
+
+// parseRetryAfter разбирает заголовок Retry-After (секунды или HTTP-дата).
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// sleepContext ждёт d или отмены контекста.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releasingBody освобождает слот домена и таймаут запроса при закрытии тела ответа.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// fetchPages скачивает страницы links параллельно (не более Fetcher.Concurrency одновременно)
+// и разбирает каждую функцией parse (pageURL — адрес страницы после редиректов).
+// Ссылки известных событий не скачиваются и возвращаются в Result.Skipped,
+// запрещённые robots.txt — в Result.Blocked, скачанные, но не разобранные — в Result.Unparsed;
+// не скачанные из-за ошибки, кроме 404 и 410, — в Result.Failed (ошибки — в Result.FailedErr);
+// Result.Events не заполняется. Результаты возвращаются в порядке ссылок; страницы,
+// которые не удалось скачать или разобрать, пропускаются.
+func fetchPages[T any](ctx context.Context, req Request, links []string, parse func(link string, doc *goquery.Document, pageURL *url.URL) (T, bool)) ([]T, Result, error) {
+	var res Result
+	var pending []string
+	for _, link := range links {
+		if req.isKnown(ctx, link) {
+			res.Skipped = append(res.Skipped, link)
+			continue
+		}
+		pending = append(pending, link)
+	}
+
+	results := make([]T, len(pending))
+	fetched := make([]bool, len(pending))
+	parsed := make([]bool, len(pending))
+	blocked := make([]bool, len(pending))
+	failed := make([]error, len(pending))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(req.Fetcher.Concurrency(), len(pending)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				doc, pageURL, err := req.Fetcher.FetchDocument(ctx, pending[i])
+				if err != nil {
+					var blockedErr *BlockedError
+					var statusErr *StatusError
+					switch {
+					case errors.As(err, &blockedErr):
+						blocked[i] = true
+					case errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusGone):
+						// Удалённая страница — не сбой: событие проверит поиск пропавших событий
+					default:
+						failed[i] = err
+					}
+					continue
+				}
+				fetched[i] = true
+				results[i], parsed[i] = parse(pending[i], doc, pageURL)
+			}
+		}()
+	}
+
+	var stopErr error
+	for i := range pending {
+		if stopErr = req.stopped(ctx); stopErr != nil {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var out []T
+	for i, ok := range parsed {
+		switch {
+		case ok:
+			out = append(out, results[i])
+		case fetched[i]:
+			res.Unparsed = append(res.Unparsed, pending[i])
+		}
+		if blocked[i] {
+			res.Blocked = append(res.Blocked, pending[i])
+		}
+		if failed[i] != nil {
+			res.Failed = append(res.Failed, pending[i])
+		}
+	}
+	res.FailedErr = errors.Join(failed...)
+
+	return out, res, stopErr
+}
@@ -0,0 +1,65 @@
+package sites
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchLimit(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		requests.Add(1)
+		w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer server.Close()
+
+	cfg := testFetchConfig
+	cfg.Retries = 2
+	fetcher := NewFetcher(cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name    string
+		limit   int64
+		wantErr error
+	}{
+		{"larger limit", 1000, nil},
+		{"exact limit", 100, nil},
+		{"body over limit", 99, ErrBodyTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			body, err := fetcher.FetchLimit(ctx, server.URL+"/page", nil, tt.limit)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("FetchLimit() error = %v, want %v", err, tt.wantErr)
+				}
+				// Слишком большой документ не скачивается повторно
+				if got := requests.Load(); got != 1 {
+					t.Errorf("requests = %d, want 1", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FetchLimit() error: %v", err)
+			}
+			if len(body) != 100 {
+				t.Errorf("body length = %d, want 100", len(body))
+			}
+		})
+	}
+}
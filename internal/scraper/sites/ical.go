@@ -0,0 +1,702 @@
+package sites
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// icalDateLayout — формат значения типа DATE.
+	icalDateLayout = "20060102"
+	// icalDateTimeLayout — формат значения типа DATE-TIME без указания UTC.
+	icalDateTimeLayout = "20060102T150405"
+	// icalUTCLayout — формат значения типа DATE-TIME в UTC.
+	icalUTCLayout = "20060102T150405Z"
+	// maxRecurrencePeriods ограничивает число периодов при разворачивании RRULE.
+	maxRecurrencePeriods = 10000
+)
+
+// icalProperty — свойство компонента iCalendar (например, DTSTART;TZID=Europe/Madrid:20250102T200000).
+type icalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// icalEvent — компонент VEVENT со всеми его свойствами.
+type icalEvent struct {
+	props map[string][]icalProperty
+}
+
+// get возвращает первое свойство с указанным именем.
+func (e icalEvent) get(name string) (icalProperty, bool) {
+	props := e.props[name]
+	if len(props) == 0 {
+		return icalProperty{}, false
+	}
+	return props[0], true
+}
+
+// text возвращает значение текстового свойства с раскрытыми escape-последовательностями.
+func (e icalEvent) text(name string) string {
+	prop, ok := e.get(name)
+	if !ok {
+		return ""
+	}
+	return unescapeICalText(prop.Value)
+}
+
+// icalOccurrence — отдельное проведение события из календаря.
+type icalOccurrence struct {
+	Event  icalEvent
+	UID    string
+	Start  time.Time
+	End    time.Time
+	AllDay bool
+}
+
+// parseICal разбирает календарь и возвращает все компоненты VEVENT.
+// Свойства вложенных компонентов (например, VALARM) игнорируются.
+func parseICal(data []byte) ([]icalEvent, error) {
+	var events []icalEvent
+	var stack []string
+	var current *icalEvent
+	isCalendar := false
+
+	for _, line := range unfoldICalLines(string(data)) {
+		prop, err := parseICalLine(line)
+		if err != nil {
+			continue
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.Value)
+			if component == "VCALENDAR" {
+				isCalendar = true
+			}
+			stack = append(stack, component)
+			if component == "VEVENT" && len(stack) == 2 {
+				current = &icalEvent{props: make(map[string][]icalProperty)}
+			}
+		case "END":
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("unexpected END:%s", prop.Value)
+			}
+			if stack[len(stack)-1] == "VEVENT" && len(stack) == 2 && current != nil {
+				events = append(events, *current)
+				current = nil
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if current != nil && len(stack) == 2 {
+				current.props[prop.Name] = append(current.props[prop.Name], prop)
+			}
+		}
+	}
+
+	if !isCalendar {
+		return nil, fmt.Errorf("not an iCalendar document")
+	}
+
+	return events, nil
+}
+
+// unfoldICalLines склеивает перенесённые строки (RFC 5545, раздел 3.1).
+func unfoldICalLines(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+
+	var lines []string
+	for _, line := range strings.Split(data, "\n") {
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// parseICalLine разбирает строку вида NAME;PARAM=VALUE;PARAM="V:A;L":VALUE.
+func parseICalLine(line string) (icalProperty, error) {
+	inQuotes := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			inQuotes = !inQuotes
+		}
+		if c == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon == -1 {
+		return icalProperty{}, fmt.Errorf("invalid content line: %s", line)
+	}
+
+	prop := icalProperty{
+		Params: make(map[string]string),
+		Value:  line[colon+1:],
+	}
+
+	parts := splitOutsideQuotes(line[:colon], ';')
+	prop.Name = strings.ToUpper(strings.TrimSpace(parts[0]))
+	for _, param := range parts[1:] {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		prop.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+
+	return prop, nil
+}
+
+// splitOutsideQuotes делит строку по разделителю, не заходя внутрь кавычек.
+func splitOutsideQuotes(s string, sep rune) []string {
+	var parts []string
+	var sb strings.Builder
+	inQuotes := false
+
+	for _, c := range s {
+		if c == '"' {
+			inQuotes = !inQuotes
+		}
+		if c == sep && !inQuotes {
+			parts = append(parts, sb.String())
+			sb.Reset()
+			continue
+		}
+		sb.WriteRune(c)
+	}
+
+	return append(parts, sb.String())
+}
+
+// unescapeICalText раскрывает escape-последовательности текстового значения.
+func unescapeICalText(s string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return strings.TrimSpace(replacer.Replace(s))
+}
+
+// parseICalTime разбирает значение DATE или DATE-TIME.
+// Время без UTC и без TZID (или с неизвестным TZID) считается заданным в часовом поясе loc.
+func parseICalTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+
+	if params["VALUE"] == "DATE" || len(value) == len(icalDateLayout) {
+		t, err := time.ParseInLocation(icalDateLayout, value, loc)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icalUTCLayout, value)
+		return t, false, err
+	}
+
+	if tzid := strings.TrimPrefix(params["TZID"], "/"); tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+
+	t, err := time.ParseInLocation(icalDateTimeLayout, value, loc)
+	return t, false, err
+}
+
+// parseICalDuration разбирает продолжительность вида P1D, PT2H30M, P1W.
+func parseICalDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign = -1
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+
+	if !strings.HasPrefix(value, "P") {
+		return 0, fmt.Errorf("invalid duration: %s", value)
+	}
+	value = value[1:]
+
+	var total time.Duration
+	var num strings.Builder
+	inTime := false
+
+	for _, c := range value {
+		switch {
+		case c >= '0' && c <= '9':
+			num.WriteRune(c)
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(num.String())
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", value)
+		}
+		num.Reset()
+
+		switch {
+		case c == 'W':
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case c == 'D':
+			total += time.Duration(n) * 24 * time.Hour
+		case c == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case c == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case c == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration: %s", value)
+		}
+	}
+
+	return sign * total, nil
+}
+
+// icalWeekdays — соответствие кодов дней недели RRULE дням недели Go.
+var icalWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// icalWeekdayNum — элемент BYDAY, например "2TU" (второй вторник) или "-1SU" (последнее воскресенье).
+type icalWeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// icalRRule — правило повторения события.
+type icalRRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []icalWeekdayNum
+	ByMonthDay []int
+	ByMonth    []int
+}
+
+// parseICalRRule разбирает значение свойства RRULE.
+func parseICalRRule(value string, loc *time.Location) (icalRRule, error) {
+	rule := icalRRule{Interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return rule, fmt.Errorf("invalid INTERVAL: %s", val)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				return rule, fmt.Errorf("invalid COUNT: %s", val)
+			}
+			rule.Count = n
+		case "UNTIL":
+			t, dateOnly, err := parseICalTime(val, nil, loc)
+			if err != nil {
+				return rule, fmt.Errorf("invalid UNTIL: %s", val)
+			}
+			// UNTIL-дата включает весь день: проведения в любое время этого дня остаются
+			if dateOnly {
+				t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+			rule.Until = t
+		case "BYDAY":
+			for _, d := range strings.Split(val, ",") {
+				d = strings.ToUpper(strings.TrimSpace(d))
+				if len(d) < 2 {
+					continue
+				}
+				day, ok := icalWeekdays[d[len(d)-2:]]
+				if !ok {
+					return rule, fmt.Errorf("invalid BYDAY: %s", d)
+				}
+				n := 0
+				if prefix := d[:len(d)-2]; prefix != "" {
+					parsed, err := strconv.Atoi(prefix)
+					if err != nil {
+						return rule, fmt.Errorf("invalid BYDAY: %s", d)
+					}
+					n = parsed
+				}
+				rule.ByDay = append(rule.ByDay, icalWeekdayNum{N: n, Day: day})
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(strings.TrimSpace(d))
+				if err != nil {
+					return rule, fmt.Errorf("invalid BYMONTHDAY: %s", d)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, m := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(strings.TrimSpace(m))
+				if err != nil {
+					return rule, fmt.Errorf("invalid BYMONTH: %s", m)
+				}
+				rule.ByMonth = append(rule.ByMonth, n)
+			}
+		}
+	}
+
+	switch rule.Freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+		return rule, nil
+	default:
+		return rule, fmt.Errorf("unsupported FREQ: %s", rule.Freq)
+	}
+}
+
+// expand возвращает начала проведений события, начинающегося в start, в окне [from, to].
+// Учитываются COUNT и UNTIL; время суток и часовой пояс берутся из start,
+// поэтому при переходе на летнее время локальное время проведения не сдвигается.
+// Без COUNT периоды до from пропускаются, поэтому ограничение maxRecurrencePeriods
+// отсчитывается от окна, а не от DTSTART давно идущего события.
+func (r icalRRule) expand(start time.Time, from time.Time, to time.Time) []time.Time {
+	var result []time.Time
+	emitted := 0
+
+	first := 0
+	if r.Count == 0 {
+		first = r.periodBefore(start, from)
+	}
+
+	for period := first; period < first+maxRecurrencePeriods; period++ {
+		candidates := r.periodCandidates(start, period)
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+
+		for _, c := range candidates {
+			if c.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && c.After(r.Until) {
+				return result
+			}
+			if c.After(to) {
+				return result
+			}
+			if r.Count > 0 && emitted >= r.Count {
+				return result
+			}
+			// Проведения до окна учитываются в COUNT, но не возвращаются
+			emitted++
+			if c.Before(from) {
+				continue
+			}
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// periodBefore возвращает номер периода правила, предшествующего периоду с моментом from.
+// Проведения этого периода могут начинаться раньше его начала (например, BYDAY недели),
+// поэтому поиск начинается на период раньше.
+func (r icalRRule) periodBefore(start time.Time, from time.Time) int {
+	if !from.After(start) {
+		return 0
+	}
+
+	var period int
+	switch r.Freq {
+	case "DAILY":
+		period = int(from.Sub(start).Hours()/24) / r.Interval
+	case "WEEKLY":
+		period = int(from.Sub(start).Hours()/24) / (7 * r.Interval)
+	case "MONTHLY":
+		period = ((from.Year()-start.Year())*12 + int(from.Month()-start.Month())) / r.Interval
+	case "YEARLY":
+		period = (from.Year() - start.Year()) / r.Interval
+	}
+	return max(period-1, 0)
+}
+
+// periodCandidates возвращает кандидатов на проведение в period-м периоде правила.
+func (r icalRRule) periodCandidates(start time.Time, period int) []time.Time {
+	loc := start.Location()
+	hour, minute, second := start.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, loc)
+	}
+
+	var candidates []time.Time
+
+	switch r.Freq {
+	case "DAILY":
+		day := start.AddDate(0, 0, period*r.Interval)
+		if r.matchesDay(day) {
+			candidates = append(candidates, day)
+		}
+
+	case "WEEKLY":
+		weekStart := start.AddDate(0, 0, period*7*r.Interval)
+		if len(r.ByDay) == 0 {
+			candidates = append(candidates, weekStart)
+			break
+		}
+		// Неделя начинается с понедельника (WKST=MO по умолчанию)
+		monday := weekStart.AddDate(0, 0, -((int(weekStart.Weekday()) + 6) % 7))
+		for _, wd := range r.ByDay {
+			day := monday.AddDate(0, 0, (int(wd.Day)+6)%7)
+			if r.matchesMonth(day) {
+				candidates = append(candidates, at(day.Year(), day.Month(), day.Day()))
+			}
+		}
+
+	case "MONTHLY":
+		first := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, loc).AddDate(0, period*r.Interval, 0)
+		if !r.matchesMonth(first) {
+			break
+		}
+		for _, day := range r.monthDays(first, start.Day()) {
+			candidates = append(candidates, at(first.Year(), first.Month(), day))
+		}
+
+	case "YEARLY":
+		year := start.Year() + period*r.Interval
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []int{int(start.Month())}
+		}
+		for _, m := range months {
+			first := time.Date(year, time.Month(m), 1, 0, 0, 0, 0, loc)
+			for _, day := range r.monthDays(first, start.Day()) {
+				candidates = append(candidates, at(year, time.Month(m), day))
+			}
+		}
+	}
+
+	return candidates
+}
+
+// monthDays возвращает дни месяца, начинающегося в first, подходящие под BYMONTHDAY или BYDAY.
+// Без этих правил используется день defaultDay, если он есть в месяце.
+func (r icalRRule) monthDays(first time.Time, defaultDay int) []int {
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+	var days []int
+
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d = daysInMonth + d + 1
+			}
+			if d >= 1 && d <= daysInMonth {
+				days = append(days, d)
+			}
+		}
+
+	case len(r.ByDay) > 0:
+		for _, wd := range r.ByDay {
+			var matches []int
+			for d := 1; d <= daysInMonth; d++ {
+				if first.AddDate(0, 0, d-1).Weekday() == wd.Day {
+					matches = append(matches, d)
+				}
+			}
+			switch {
+			case wd.N == 0:
+				days = append(days, matches...)
+			case wd.N > 0 && wd.N <= len(matches):
+				days = append(days, matches[wd.N-1])
+			case wd.N < 0 && -wd.N <= len(matches):
+				days = append(days, matches[len(matches)+wd.N])
+			}
+		}
+
+	default:
+		if defaultDay <= daysInMonth {
+			days = append(days, defaultDay)
+		}
+	}
+
+	return days
+}
+
+// matchesDay проверяет дату по BYDAY и BYMONTH для ежедневных правил.
+func (r icalRRule) matchesDay(t time.Time) bool {
+	if !r.matchesMonth(t) {
+		return false
+	}
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if wd.Day == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonth проверяет дату по BYMONTH.
+func (r icalRRule) matchesMonth(t time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if time.Month(m) == t.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+// icalOccurrences разворачивает события календаря в отдельные проведения в окне [from, to].
+// Повторяющиеся события разворачиваются по RRULE/RDATE с учётом EXDATE и RECURRENCE-ID.
+// Проведения дедуплицируются по UID и времени начала; из нескольких версий события
+// остаётся версия с наибольшим SEQUENCE.
+func icalOccurrences(events []icalEvent, from time.Time, to time.Time, loc *time.Location) []icalOccurrence {
+	type entry struct {
+		occurrence icalOccurrence
+		sequence   int
+	}
+
+	byKey := make(map[string]entry)
+	var order []string
+
+	add := func(occ icalOccurrence, sequence int) {
+		if occ.End.Before(from) || occ.Start.After(to) {
+			return
+		}
+		key := occ.UID + "|" + strconv.FormatInt(occ.Start.Unix(), 10)
+		if prev, ok := byKey[key]; ok {
+			if sequence >= prev.sequence {
+				byKey[key] = entry{occurrence: occ, sequence: sequence}
+			}
+			return
+		}
+		byKey[key] = entry{occurrence: occ, sequence: sequence}
+		order = append(order, key)
+	}
+
+	// Переопределённые проведения (RECURRENCE-ID) заменяют проведения из RRULE
+	overridden := make(map[string]bool)
+	for _, e := range events {
+		if prop, ok := e.get("RECURRENCE-ID"); ok {
+			if t, _, err := parseICalTime(prop.Value, prop.Params, loc); err == nil {
+				overridden[e.text("UID")+"|"+strconv.FormatInt(t.Unix(), 10)] = true
+			}
+		}
+	}
+
+	for _, e := range events {
+		dtstart, ok := e.get("DTSTART")
+		if !ok {
+			continue
+		}
+		start, allDay, err := parseICalTime(dtstart.Value, dtstart.Params, loc)
+		if err != nil {
+			continue
+		}
+
+		duration := icalEventDuration(e, start, allDay, loc)
+		uid := e.text("UID")
+		if uid == "" {
+			uid = e.text("SUMMARY") + "|" + start.Format(icalUTCLayout)
+		}
+		sequence, _ := strconv.Atoi(e.text("SEQUENCE"))
+
+		newOccurrence := func(s time.Time) icalOccurrence {
+			return icalOccurrence{Event: e, UID: uid, Start: s, End: s.Add(duration), AllDay: allDay}
+		}
+
+		rruleProp, isRecurring := e.get("RRULE")
+		_, isOverride := e.get("RECURRENCE-ID")
+		if !isRecurring || isOverride {
+			add(newOccurrence(start), sequence)
+			continue
+		}
+
+		rule, err := parseICalRRule(rruleProp.Value, loc)
+		if err != nil {
+			add(newOccurrence(start), sequence)
+			continue
+		}
+
+		excluded := make(map[int64]bool)
+		for _, prop := range e.props["EXDATE"] {
+			for _, v := range strings.Split(prop.Value, ",") {
+				if t, _, err := parseICalTime(v, prop.Params, loc); err == nil {
+					excluded[t.Unix()] = true
+				}
+			}
+		}
+
+		// Проведения, начавшиеся до from, но ещё идущие, тоже попадают в окно
+		starts := rule.expand(start, from.Add(-duration), to)
+		for _, prop := range e.props["RDATE"] {
+			for _, v := range strings.Split(prop.Value, ",") {
+				if t, _, err := parseICalTime(v, prop.Params, loc); err == nil {
+					starts = append(starts, t)
+				}
+			}
+		}
+
+		for _, s := range starts {
+			if excluded[s.Unix()] || overridden[uid+"|"+strconv.FormatInt(s.Unix(), 10)] {
+				continue
+			}
+			add(newOccurrence(s), sequence)
+		}
+	}
+
+	result := make([]icalOccurrence, 0, len(order))
+	for _, key := range order {
+		result = append(result, byKey[key].occurrence)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+
+	return result
+}
+
+// icalEventDuration вычисляет продолжительность события по DTEND или DURATION.
+// Для событий на весь день без окончания продолжительность — один день.
+func icalEventDuration(e icalEvent, start time.Time, allDay bool, loc *time.Location) time.Duration {
+	if prop, ok := e.get("DTEND"); ok {
+		if end, _, err := parseICalTime(prop.Value, prop.Params, loc); err == nil && !end.Before(start) {
+			return end.Sub(start)
+		}
+	}
+	if prop, ok := e.get("DURATION"); ok {
+		if d, err := parseICalDuration(prop.Value); err == nil && d >= 0 {
+			return d
+		}
+	}
+	if allDay {
+		return 24 * time.Hour
+	}
+	return 0
+}
@@ -0,0 +1,179 @@
+package sites
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseICalDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"P1D", 24 * time.Hour, false},
+		{"PT2H30M", 2*time.Hour + 30*time.Minute, false},
+		{"P1W", 7 * 24 * time.Hour, false},
+		{"P1DT2H", 26 * time.Hour, false},
+		{"+PT45S", 45 * time.Second, false},
+		{"-PT15M", -15 * time.Minute, false},
+		{" PT1H ", time.Hour, false},
+		{"P0D", 0, false},
+		{"1D", 0, true},
+		{"P1M", 0, true}, // Месяцы в DURATION не допускаются, а M без T — не минуты
+		{"PT1X", 0, true},
+		{"PTH", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseICalDuration(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseICalDuration(%q) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestICalRRuleExpand(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, madrid)
+	}
+
+	tests := []struct {
+		name  string
+		rrule string
+		start time.Time
+		from  time.Time
+		to    time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "daily count",
+			rrule: "FREQ=DAILY;COUNT=3",
+			start: at(2025, 3, 10, 20, 0),
+			from:  at(2025, 3, 1, 0, 0),
+			to:    at(2025, 4, 1, 0, 0),
+			want:  []time.Time{at(2025, 3, 10, 20, 0), at(2025, 3, 11, 20, 0), at(2025, 3, 12, 20, 0)},
+		},
+		{
+			name:  "count includes occurrences before the window",
+			rrule: "FREQ=DAILY;COUNT=5",
+			start: at(2025, 3, 1, 20, 0),
+			from:  at(2025, 3, 4, 0, 0),
+			to:    at(2025, 4, 1, 0, 0),
+			want:  []time.Time{at(2025, 3, 4, 20, 0), at(2025, 3, 5, 20, 0)},
+		},
+		{
+			name:  "date-only until keeps timed occurrence on the last day",
+			rrule: "FREQ=DAILY;UNTIL=20250312",
+			start: at(2025, 3, 10, 20, 0),
+			from:  at(2025, 3, 1, 0, 0),
+			to:    at(2025, 4, 1, 0, 0),
+			want:  []time.Time{at(2025, 3, 10, 20, 0), at(2025, 3, 11, 20, 0), at(2025, 3, 12, 20, 0)},
+		},
+		{
+			name:  "utc until",
+			rrule: "FREQ=DAILY;UNTIL=20250311T190000Z",
+			start: at(2025, 3, 10, 20, 0),
+			from:  at(2025, 3, 1, 0, 0),
+			to:    at(2025, 4, 1, 0, 0),
+			want:  []time.Time{at(2025, 3, 10, 20, 0), at(2025, 3, 11, 20, 0)},
+		},
+		{
+			name:  "weekly by day",
+			rrule: "FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20250320T235959Z",
+			start: at(2025, 3, 11, 19, 30),
+			from:  at(2025, 3, 1, 0, 0),
+			to:    at(2025, 4, 1, 0, 0),
+			want:  []time.Time{at(2025, 3, 11, 19, 30), at(2025, 3, 13, 19, 30), at(2025, 3, 18, 19, 30), at(2025, 3, 20, 19, 30)},
+		},
+		{
+			name:  "weekly keeps local time across daylight saving change",
+			rrule: "FREQ=WEEKLY;COUNT=2",
+			start: at(2025, 3, 23, 20, 0),
+			from:  at(2025, 3, 1, 0, 0),
+			to:    at(2025, 4, 1, 0, 0),
+			want:  []time.Time{at(2025, 3, 23, 20, 0), at(2025, 3, 30, 20, 0)},
+		},
+		{
+			name:  "monthly last friday",
+			rrule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			start: at(2025, 1, 31, 21, 0),
+			from:  at(2025, 1, 1, 0, 0),
+			to:    at(2025, 12, 31, 0, 0),
+			want:  []time.Time{at(2025, 1, 31, 21, 0), at(2025, 2, 28, 21, 0), at(2025, 3, 28, 21, 0)},
+		},
+		{
+			name:  "monthly day 31 skips short months",
+			rrule: "FREQ=MONTHLY;BYMONTHDAY=31",
+			start: at(2025, 1, 31, 10, 0),
+			from:  at(2025, 1, 1, 0, 0),
+			to:    at(2025, 6, 1, 0, 0),
+			want:  []time.Time{at(2025, 1, 31, 10, 0), at(2025, 3, 31, 10, 0), at(2025, 5, 31, 10, 0)},
+		},
+		{
+			name:  "yearly",
+			rrule: "FREQ=YEARLY",
+			start: at(2019, 6, 23, 22, 0),
+			from:  at(2024, 1, 1, 0, 0),
+			to:    at(2026, 1, 1, 0, 0),
+			want:  []time.Time{at(2024, 6, 23, 22, 0), at(2025, 6, 23, 22, 0)},
+		},
+		{
+			// Раньше ограничение числа периодов отсчитывалось от DTSTART, и окно оставалось пустым
+			name:  "long-running daily rule with old start",
+			rrule: "FREQ=DAILY",
+			start: at(1990, 1, 1, 20, 0),
+			from:  at(2025, 3, 10, 0, 0),
+			to:    at(2025, 3, 12, 23, 59),
+			want:  []time.Time{at(2025, 3, 10, 20, 0), at(2025, 3, 11, 20, 0), at(2025, 3, 12, 20, 0)},
+		},
+		{
+			name:  "skip ahead keeps interval parity",
+			rrule: "FREQ=WEEKLY;INTERVAL=2",
+			start: at(2020, 1, 6, 18, 0),
+			from:  at(2025, 3, 1, 0, 0),
+			to:    at(2025, 3, 31, 23, 59),
+			want:  []time.Time{at(2025, 3, 10, 18, 0), at(2025, 3, 24, 18, 0)},
+		},
+		{
+			name:  "skip ahead keeps by day before week anchor",
+			rrule: "FREQ=WEEKLY;BYDAY=MO,SA",
+			start: at(2010, 1, 9, 12, 0), // Суббота
+			from:  at(2025, 3, 10, 0, 0),
+			to:    at(2025, 3, 16, 23, 59),
+			want:  []time.Time{at(2025, 3, 10, 12, 0), at(2025, 3, 15, 12, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseICalRRule(tt.rrule, madrid)
+			if err != nil {
+				t.Fatalf("parseICalRRule(%q): %v", tt.rrule, err)
+			}
+			got := rule.expand(tt.start, tt.from, tt.to)
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("expand() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseICalRRuleErrors(t *testing.T) {
+	for _, rrule := range []string{
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=x",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;UNTIL=tomorrow",
+	} {
+		if _, err := parseICalRRule(rrule, time.UTC); err == nil {
+			t.Errorf("parseICalRRule(%q) error = nil", rrule)
+		}
+	}
+}
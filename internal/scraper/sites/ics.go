@@ -0,0 +1,160 @@
+package sites
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+)
+
+// defaultICSHorizonDays — горизонт разворачивания повторяющихся событий по умолчанию.
+const defaultICSHorizonDays = 90
+
+// icsScraper — источник событий из календаря в формате iCalendar (.ics).
+type icsScraper struct {
+	horizon  time.Duration
+	location *time.Location
+}
+
+// NewICSScraper создаёт ScrapeFunc для календаря в формате iCalendar (RFC 5545).
+// Возвращает ошибку, если часовой пояс в конфигурации некорректен.
+func NewICSScraper(cfg config.ICSConfig) (ScrapeFunc, error) {
+	horizonDays := cfg.HorizonDays
+	if horizonDays <= 0 {
+		horizonDays = defaultICSHorizonDays
+	}
+
+	s := &icsScraper{
+		horizon:  time.Duration(horizonDays) * 24 * time.Hour,
+		location: time.UTC,
+	}
+
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("ics scraper: invalid timezone: %w", err)
+		}
+		s.location = loc
+	}
+
+	return s.scrape, nil
+}
+
+// scrape скачивает календарь и преобразует будущие проведения событий в domain.Event.
+func (s *icsScraper) scrape(ctx context.Context, req Request) (Result, error) {
+	if err := req.stopped(ctx); err != nil {
+		return Result{}, err
+	}
+
+	feedURL := req.URL
+	body, err := req.Fetcher.Fetch(ctx, feedURL, nil)
+	if err != nil {
+		return Result{}, err
+	}
+
+	calendarEvents, err := parseICal(body)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to parse calendar %s: %w", feedURL, err)
+	}
+
+	now := time.Now()
+	occurrences := icalOccurrences(calendarEvents, now, now.Add(s.horizon), s.location)
+
+	events := make([]domain.Event, 0, len(occurrences))
+	for _, occ := range occurrences {
+		event := s.toDomain(feedURL, occ)
+		if event.Name == "" {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return Result{Events: events}, nil
+}
+
+// toDomain преобразует проведение события из календаря в domain.Event.
+func (s *icsScraper) toDomain(feedURL string, occ icalOccurrence) domain.Event {
+	e := occ.Event
+
+	event := domain.Event{
+		Name:        e.text("SUMMARY"),
+		Description: e.text("DESCRIPTION"),
+		Date:        occ.Start,
+		EndDate:     occ.End,
+		AllDay:      occ.AllDay,
+		EventLink:   e.text("URL"),
+		Status:      domain.EventStatusNew,
+	}
+	// DTEND событий на целые дни не входит в событие: храним последний день
+	if occ.AllDay {
+		event.EndDate = occ.End.AddDate(0, 0, -1)
+	}
+
+	// Без URL ссылкой на событие служит календарь с UID события
+	if event.EventLink == "" {
+		event.EventLink = feedURL + "#" + url.PathEscape(occ.UID)
+	}
+
+	event.Venue = icalVenue(e)
+	event.MapLink = event.Venue.MapLink()
+
+	event.Photo = icalImage(e)
+
+	return event
+}
+
+// icalVenue возвращает место проведения события из свойств LOCATION и GEO ("широта;долгота").
+func icalVenue(e icalEvent) domain.Venue {
+	venue := domain.Venue{Address: e.text("LOCATION")}
+	if venue.IsZero() {
+		return venue
+	}
+
+	if lat, lon, ok := strings.Cut(e.text("GEO"), ";"); ok {
+		latitude, latErr := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+		longitude, lonErr := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+		if latErr == nil && lonErr == nil {
+			venue.Latitude, venue.Longitude = latitude, longitude
+		}
+	}
+	return venue
+}
+
+// icalImage возвращает ссылку на изображение события из свойств IMAGE (RFC 7986) или ATTACH.
+func icalImage(e icalEvent) string {
+	for _, name := range []string{"IMAGE", "ATTACH"} {
+		for _, prop := range e.props[name] {
+			if prop.Params["VALUE"] == "BINARY" || prop.Params["ENCODING"] == "BASE64" {
+				continue
+			}
+			value := strings.TrimSpace(prop.Value)
+			if !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+				continue
+			}
+			if name == "IMAGE" || strings.HasPrefix(prop.Params["FMTTYPE"], "image/") || isImageURL(value) {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// isImageURL проверяет, указывает ли ссылка на файл изображения по расширению.
+func isImageURL(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	path := strings.ToLower(u.Path)
+	for _, ext := range []string{".jpg", ".jpeg", ".png", ".gif", ".webp"} {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,369 @@
+package sites
+
+import (
+	"encoding/json"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"eventsBot/internal/models/domain"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// jsonLDDateLayouts — форматы дат, встречающиеся в startDate/endDate.
+var jsonLDDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// jsonLDEventTypes — типы schema.org, являющиеся мероприятиями, но не оканчивающиеся на "Event".
+var jsonLDEventTypes = map[string]bool{
+	"Festival":       true,
+	"Hackathon":      true,
+	"CourseInstance": true,
+}
+
+// htmlTagRegex — выражение для удаления HTML-тегов из текстовых полей JSON-LD.
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// jsonLDEvent — мероприятие schema.org, найденное в JSON-LD.
+type jsonLDEvent struct {
+	Name        string
+	Description string
+	Date        time.Time
+	EndDate     time.Time
+	AllDay      bool
+	Price       domain.Price
+	Photo       string
+	Venue       domain.Venue
+	EventLink   string
+}
+
+// extractJSONLDEvents извлекает мероприятия schema.org из блоков
+// <script type="application/ld+json"> документа. Относительные ссылки разрешаются
+// относительно pageURL, даты без смещения считаются заданными в часовом поясе loc.
+func extractJSONLDEvents(doc *goquery.Document, pageURL *url.URL, loc *time.Location) []jsonLDEvent {
+	var events []jsonLDEvent
+
+	doc.Find(`script[type="application/ld+json"]`).Each(func(i int, sel *goquery.Selection) {
+		var data any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(sel.Text())), &data); err != nil {
+			return
+		}
+		walkJSONLD(data, func(node map[string]any) {
+			events = append(events, parseJSONLDEvent(node, pageURL, loc))
+		})
+	})
+
+	return events
+}
+
+// walkJSONLD обходит JSON-LD (включая @graph и вложенные объекты) и вызывает fn
+// для каждого объекта с типом мероприятия. Внутрь найденных мероприятий обход не заходит.
+func walkJSONLD(data any, fn func(node map[string]any)) {
+	switch v := data.(type) {
+	case []any:
+		for _, item := range v {
+			walkJSONLD(item, fn)
+		}
+	case map[string]any:
+		if isJSONLDEvent(v["@type"]) {
+			fn(v)
+			return
+		}
+		for _, value := range v {
+			walkJSONLD(value, fn)
+		}
+	}
+}
+
+// isJSONLDEvent проверяет, является ли @type (строка или массив) типом мероприятия.
+func isJSONLDEvent(t any) bool {
+	switch v := t.(type) {
+	case string:
+		name := v[strings.LastIndexAny(v, "/:")+1:]
+		return strings.HasSuffix(name, "Event") || jsonLDEventTypes[name]
+	case []any:
+		for _, item := range v {
+			if isJSONLDEvent(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseJSONLDEvent заполняет jsonLDEvent из объекта schema.org Event.
+func parseJSONLDEvent(node map[string]any, pageURL *url.URL, loc *time.Location) jsonLDEvent {
+	event := jsonLDEvent{
+		Name:        jsonLDText(node["name"]),
+		Description: jsonLDText(node["description"]),
+	}
+
+	startDate := jsonString(node["startDate"])
+	if t, ok := parseDate(startDate, jsonLDDateLayouts, loc); ok {
+		event.Date = t
+		event.AllDay = isDateOnly(startDate)
+	}
+	if t, ok := parseDate(jsonString(node["endDate"]), jsonLDDateLayouts, loc); ok {
+		event.EndDate = t
+	}
+
+	event.Price = jsonLDOffers(node["offers"])
+	if jsonBool(node["isAccessibleForFree"]) {
+		event.Price.Free = true
+		event.Price.Normalize()
+	}
+
+	if photo := jsonLDImage(node["image"]); photo != "" {
+		event.Photo = resolveURL(pageURL, photo)
+	}
+
+	event.Venue = jsonLDLocation(node["location"])
+
+	if link := jsonString(node["url"]); link != "" {
+		event.EventLink = resolveURL(pageURL, link)
+	} else if pageURL != nil {
+		event.EventLink = pageURL.String()
+	}
+
+	return event
+}
+
+// toDomain преобразует мероприятие JSON-LD в domain.Event.
+func (e jsonLDEvent) toDomain() domain.Event {
+	event := domain.Event{
+		Name:        e.Name,
+		Description: e.Description,
+		Date:        e.Date,
+		EndDate:     e.EndDate,
+		AllDay:      e.AllDay,
+		Price:       e.Price,
+		Photo:       e.Photo,
+		MapLink:     e.Venue.MapLink(),
+		Venue:       e.Venue,
+		EventLink:   e.EventLink,
+		Status:      domain.EventStatusNew,
+	}
+	if place := e.Venue.Label(); place != "" {
+		event.Description = strings.TrimSpace(event.Description + "\n\nМесто: " + place)
+	}
+	return event
+}
+
+// fillEmpty дополняет незаполненные поля события данными из JSON-LD.
+func (e jsonLDEvent) fillEmpty(event *domain.Event) {
+	if event.Name == "" {
+		event.Name = e.Name
+	}
+	if event.Description == "" {
+		event.Description = e.Description
+	}
+	if event.Date.IsZero() {
+		event.Date = e.Date
+		event.EndDate = e.EndDate
+		event.AllDay = e.AllDay
+	}
+	if event.Price.IsZero() {
+		event.Price = e.Price
+	}
+	if event.Photo == "" {
+		event.Photo = e.Photo
+	}
+	if event.Venue.IsZero() {
+		event.Venue = e.Venue
+	}
+	if event.MapLink == "" {
+		event.MapLink = e.Venue.MapLink()
+	}
+}
+
+// jsonString возвращает строковое значение JSON-поля (строки или числа), в том числе первое из массива.
+func jsonString(v any) string {
+	switch value := v.(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case []any:
+		for _, item := range value {
+			if s := jsonString(item); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// jsonBool возвращает логическое значение JSON-поля (true или строка "true" в любом регистре).
+func jsonBool(v any) bool {
+	switch value := v.(type) {
+	case bool:
+		return value
+	case string:
+		return strings.EqualFold(strings.TrimSpace(value), "true")
+	}
+	return false
+}
+
+// jsonLDText возвращает текстовое значение поля без HTML-разметки.
+func jsonLDText(v any) string {
+	s := htmlTagRegex.ReplaceAllString(jsonString(v), " ")
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+// jsonLDImage возвращает ссылку на изображение из строки, массива или ImageObject.
+func jsonLDImage(v any) string {
+	switch value := v.(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case []any:
+		for _, item := range value {
+			if s := jsonLDImage(item); s != "" {
+				return s
+			}
+		}
+	case map[string]any:
+		if s := jsonString(value["url"]); s != "" {
+			return s
+		}
+		return jsonString(value["contentUrl"])
+	}
+	return ""
+}
+
+// jsonLDOffers возвращает цену из Offer, AggregateOffer или массива предложений:
+// диапазон цен всех предложений, валюту и наличие билетов.
+func jsonLDOffers(v any) domain.Price {
+	var offers []map[string]any
+	switch value := v.(type) {
+	case map[string]any:
+		offers = append(offers, value)
+	case []any:
+		for _, item := range value {
+			if offer, ok := item.(map[string]any); ok {
+				offers = append(offers, offer)
+			}
+		}
+	}
+
+	var price domain.Price
+	var amounts []float64
+	inStock, soldOut := false, false
+
+	for _, offer := range offers {
+		var offerAmounts []float64
+		for _, key := range []string{"price", "lowPrice", "highPrice"} {
+			offerAmounts = append(offerAmounts, parseAmounts(jsonString(offer[key]), nil)...)
+		}
+		if len(offerAmounts) > 0 && price.Currency == "" {
+			price.Currency = jsonString(offer["priceCurrency"])
+		}
+		amounts = append(amounts, offerAmounts...)
+
+		// Наличие задаётся ссылкой schema.org: "https://schema.org/SoldOut", "InStock" и т.п.
+		switch availability := jsonString(offer["availability"]); {
+		case strings.HasSuffix(availability, "SoldOut"), strings.HasSuffix(availability, "OutOfStock"):
+			soldOut = true
+		case availability != "":
+			inStock = true
+		}
+	}
+
+	for i, amount := range amounts {
+		if i == 0 || amount < price.Min {
+			price.Min = amount
+		}
+		price.Max = max(price.Max, amount)
+	}
+	if len(amounts) > 0 && price.Max == 0 {
+		price.Free = true
+	}
+
+	// Распроданы только если распроданы все предложения
+	switch {
+	case inStock:
+		price.Availability = domain.TicketAvailabilityAvailable
+	case soldOut:
+		price.Availability = domain.TicketAvailabilitySoldOut
+	}
+
+	price.Normalize()
+	return price
+}
+
+// jsonLDLocation возвращает место проведения из строки, Place или массива мест:
+// название, адрес и координаты (geo), если они указаны.
+func jsonLDLocation(v any) domain.Venue {
+	switch value := v.(type) {
+	case string:
+		return domain.Venue{Address: strings.TrimSpace(value)}
+	case []any:
+		for _, item := range value {
+			if venue := jsonLDLocation(item); !venue.IsZero() {
+				return venue
+			}
+		}
+	case map[string]any:
+		venue := domain.Venue{
+			Name:    jsonLDText(value["name"]),
+			Address: jsonLDAddress(value["address"]),
+		}
+		if geo, ok := value["geo"].(map[string]any); ok {
+			lat, latErr := strconv.ParseFloat(jsonString(geo["latitude"]), 64)
+			lon, lonErr := strconv.ParseFloat(jsonString(geo["longitude"]), 64)
+			if latErr == nil && lonErr == nil {
+				venue.Latitude, venue.Longitude = lat, lon
+			}
+		}
+		return venue
+	}
+	return domain.Venue{}
+}
+
+// jsonLDAddress превращает адрес (строку или PostalAddress) в одну строку.
+func jsonLDAddress(v any) string {
+	switch value := v.(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case map[string]any:
+		var parts []string
+		for _, key := range []string{"streetAddress", "postalCode", "addressLocality", "addressRegion"} {
+			if s := jsonLDText(value[key]); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		switch country := value["addressCountry"].(type) {
+		case string:
+			parts = append(parts, country)
+		case map[string]any:
+			if s := jsonLDText(country["name"]); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
+
+// resolveURL разрешает ссылку относительно base. При ошибке ссылка возвращается как есть.
+func resolveURL(base *url.URL, link string) string {
+	if base == nil {
+		return link
+	}
+	u, err := base.Parse(link)
+	if err != nil {
+		return link
+	}
+	return u.String()
+}
@@ -0,0 +1,107 @@
+package sites
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// jsonLDScraper — скрапер, берущий события только из разметки schema.org (JSON-LD).
+type jsonLDScraper struct {
+	cfg      config.SelectorConfig
+	location *time.Location
+}
+
+// NewJSONLDScraper создаёт ScrapeFunc, извлекающий события schema.org со страницы.
+// Если в конфигурации задан селектор listItem, скрапер также переходит по найденным
+// ссылкам и извлекает события со страниц мероприятий.
+func NewJSONLDScraper(cfg config.SelectorConfig) (ScrapeFunc, error) {
+	if cfg.LinkAttr == "" {
+		cfg.LinkAttr = "href"
+	}
+
+	s := &jsonLDScraper{cfg: cfg, location: time.UTC}
+
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("jsonld scraper: invalid timezone: %w", err)
+		}
+		s.location = loc
+	}
+
+	return s.scrape, nil
+}
+
+// scrape извлекает события со страницы списка и со страниц мероприятий.
+// Страницы мероприятий скачиваются параллельно, страницы известных событий пропускаются.
+func (s *jsonLDScraper) scrape(ctx context.Context, req Request) (Result, error) {
+	doc, pageURL, err := req.Fetcher.FetchDocument(ctx, req.URL)
+	if err != nil {
+		return Result{}, err
+	}
+	events := s.pageEvents(doc, pageURL)
+
+	pages, res, err := fetchPages(ctx, req, s.eventLinks(doc, pageURL), func(link string, doc *goquery.Document, pageURL *url.URL) ([]domain.Event, bool) {
+		pageEvents := s.pageEvents(doc, pageURL)
+		return pageEvents, len(pageEvents) > 0
+	})
+	for _, pageEvents := range pages {
+		events = append(events, pageEvents...)
+	}
+
+	res.Events = uniqueEvents(events)
+	return res, err
+}
+
+// pageEvents возвращает события из JSON-LD страницы.
+func (s *jsonLDScraper) pageEvents(doc *goquery.Document, pageURL *url.URL) []domain.Event {
+	var events []domain.Event
+	for _, ld := range extractJSONLDEvents(doc, pageURL, s.location) {
+		if ld.Name == "" {
+			continue
+		}
+		events = append(events, ld.toDomain())
+	}
+	return events
+}
+
+// eventLinks возвращает ссылки на страницы мероприятий, если задан селектор listItem.
+func (s *jsonLDScraper) eventLinks(doc *goquery.Document, pageURL *url.URL) []string {
+	if s.cfg.ListItem == "" {
+		return nil
+	}
+
+	var eventLinks []string
+	doc.Find(s.cfg.ListItem).Each(func(i int, sel *goquery.Selection) {
+		if href, ok := sel.Attr(s.cfg.LinkAttr); ok {
+			if absoluteURL, err := pageURL.Parse(strings.TrimSpace(href)); err == nil {
+				eventLinks = append(eventLinks, absoluteURL.String())
+			}
+		}
+	})
+
+	return uniqueStrings(eventLinks)
+}
+
+// uniqueEvents удаляет события с одинаковыми ссылкой и датой.
+func uniqueEvents(events []domain.Event) []domain.Event {
+	seen := make(map[string]bool)
+	result := make([]domain.Event, 0, len(events))
+	for _, e := range events {
+		key := e.EventLink + "|" + e.Date.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, e)
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package sites
+
+import (
+	"reflect"
+
+	"eventsBot/internal/config"
+)
+
+// mecDefaults — селекторы разметки WordPress-плагина Modern Events Calendar (MEC).
+var mecDefaults = config.SelectorConfig{
+	ListItem:    "article.mec-event-article a.mec-color-hover",
+	Name:        "h1.mec-single-title, .mec-single-event-title",
+	Description: ".mec-single-event-description",
+	Date:        ".mec-single-event-date .mec-start-date-label",
+	EndDate:     ".mec-single-event-date .mec-end-date-label",
+	DateLayouts: []string{"02 Jan 2006", "2 Jan 2006", "January 2, 2006"},
+	Time:        ".mec-single-event-time .mec-events-abbr",
+	TimeLayouts: []string{"15:04", "3:04 pm", "3:04 PM"},
+	Price:       ".mec-event-cost, dd.mec-events-event-cost",
+	Photo:       ".mec-events-event-image img",
+	Video:       `.mec-single-event-description iframe[src*="youtube"]`,
+	BuyLink:     ".mec-booking-button",
+	Venue:       ".mec-single-event-location .author",
+	Address:     ".mec-single-event-location .mec-address",
+}
+
+// NewMECScraper создаёт скрапер для сайта на Modern Events Calendar.
+// Непустые поля overrides заменяют соответствующие селекторы и настройки MEC по умолчанию,
+// например язык дат, валюту и часовой пояс конкретного сайта.
+func NewMECScraper(overrides config.SelectorConfig) (ScrapeFunc, error) {
+	return NewSelectorScraper(mergeSelectors(mecDefaults, overrides))
+}
+
+// mergeSelectors возвращает копию base, в которой непустые поля override заменяют значения base.
+func mergeSelectors(base config.SelectorConfig, override config.SelectorConfig) config.SelectorConfig {
+	result := base
+	dst := reflect.ValueOf(&result).Elem()
+	src := reflect.ValueOf(override)
+
+	for i := 0; i < src.NumField(); i++ {
+		if !src.Field(i).IsZero() {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,325 @@
+package sites
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"eventsBot/internal/models/domain"
+)
+
+// defaultPriceRegex — выражение для поиска чисел в тексте цены по умолчанию.
+var defaultPriceRegex = regexp.MustCompile(`\d+(?:[.,]\d+)?`)
+
+// clockRegex — выражение для поиска времени вида "20:00" или "8:00 pm" в тексте.
+var clockRegex = regexp.MustCompile(`(?i)\d{1,2}:\d{2}(?:\s*[ap]\.?m\.?)?`)
+
+// wordRegex — выражение для поиска слов (в том числе сокращений с точкой) в тексте даты.
+var wordRegex = regexp.MustCompile(`\p{L}+\.?`)
+
+// monthNames — названия месяцев и их сокращения для поддерживаемых языков.
+// Ключи в нижнем регистре, без точки на конце.
+var monthNames = map[string]map[string]time.Month{
+	"es": {
+		"enero": time.January, "ene": time.January,
+		"febrero": time.February, "feb": time.February,
+		"marzo": time.March, "mar": time.March,
+		"abril": time.April, "abr": time.April,
+		"mayo": time.May, "may": time.May,
+		"junio": time.June, "jun": time.June,
+		"julio": time.July, "jul": time.July,
+		"agosto": time.August, "ago": time.August,
+		"septiembre": time.September, "setiembre": time.September, "sep": time.September, "sept": time.September,
+		"octubre": time.October, "oct": time.October,
+		"noviembre": time.November, "nov": time.November,
+		"diciembre": time.December, "dic": time.December,
+	},
+	"ca": {
+		"gener": time.January, "gen": time.January,
+		"febrer": time.February, "febr": time.February,
+		"març": time.March, "mar": time.March,
+		"abril": time.April, "abr": time.April,
+		"maig":   time.May,
+		"juny":   time.June,
+		"juliol": time.July, "jul": time.July,
+		"agost": time.August, "ag": time.August,
+		"setembre": time.September, "set": time.September,
+		"octubre": time.October, "oct": time.October,
+		"novembre": time.November, "nov": time.November,
+		"desembre": time.December, "des": time.December,
+	},
+	"ru": {
+		"января": time.January, "январь": time.January, "янв": time.January,
+		"февраля": time.February, "февраль": time.February, "фев": time.February,
+		"марта": time.March, "март": time.March, "мар": time.March,
+		"апреля": time.April, "апрель": time.April, "апр": time.April,
+		"мая": time.May, "май": time.May,
+		"июня": time.June, "июнь": time.June, "июн": time.June,
+		"июля": time.July, "июль": time.July, "июл": time.July,
+		"августа": time.August, "август": time.August, "авг": time.August,
+		"сентября": time.September, "сентябрь": time.September, "сен": time.September,
+		"октября": time.October, "октябрь": time.October, "окт": time.October,
+		"ноября": time.November, "ноябрь": time.November, "ноя": time.November,
+		"декабря": time.December, "декабрь": time.December, "дек": time.December,
+	},
+	"de": {
+		"januar": time.January, "jan": time.January,
+		"februar": time.February, "feb": time.February,
+		"märz": time.March, "mär": time.March,
+		"april": time.April, "apr": time.April,
+		"mai":  time.May,
+		"juni": time.June, "jun": time.June,
+		"juli": time.July, "jul": time.July,
+		"august": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September,
+		"oktober": time.October, "okt": time.October,
+		"november": time.November, "nov": time.November,
+		"dezember": time.December, "dez": time.December,
+	},
+	"fr": {
+		"janvier": time.January, "janv": time.January,
+		"février": time.February, "févr": time.February,
+		"mars":  time.March,
+		"avril": time.April, "avr": time.April,
+		"mai":     time.May,
+		"juin":    time.June,
+		"juillet": time.July, "juil": time.July,
+		"août":      time.August,
+		"septembre": time.September, "sept": time.September,
+		"octobre": time.October, "oct": time.October,
+		"novembre": time.November, "nov": time.November,
+		"décembre": time.December, "déc": time.December,
+	},
+	"it": {
+		"gennaio": time.January, "gen": time.January,
+		"febbraio": time.February, "feb": time.February,
+		"marzo": time.March, "mar": time.March,
+		"aprile": time.April, "apr": time.April,
+		"maggio": time.May, "mag": time.May,
+		"giugno": time.June, "giu": time.June,
+		"luglio": time.July, "lug": time.July,
+		"agosto": time.August, "ago": time.August,
+		"settembre": time.September, "set": time.September,
+		"ottobre": time.October, "ott": time.October,
+		"novembre": time.November, "nov": time.November,
+		"dicembre": time.December, "dic": time.December,
+	},
+	"pt": {
+		"janeiro": time.January, "jan": time.January,
+		"fevereiro": time.February, "fev": time.February,
+		"março": time.March, "mar": time.March,
+		"abril": time.April, "abr": time.April,
+		"maio": time.May, "mai": time.May,
+		"junho": time.June, "jun": time.June,
+		"julho": time.July, "jul": time.July,
+		"agosto": time.August, "ago": time.August,
+		"setembro": time.September, "set": time.September,
+		"outubro": time.October, "out": time.October,
+		"novembro": time.November, "nov": time.November,
+		"dezembro": time.December, "dez": time.December,
+	},
+}
+
+// cleanText убирает переводы строк, табуляцию и пробелы по краям.
+func cleanText(s string) string {
+	s = strings.ReplaceAll(s, "\n", "")
+	s = strings.ReplaceAll(s, "\t", "")
+	return strings.TrimSpace(s)
+}
+
+// parseAmounts возвращает все числа, найденные в тексте цены выражением re
+// (defaultPriceRegex, если re не задано). Десятичная запятая заменяется точкой.
+func parseAmounts(text string, re *regexp.Regexp) []float64 {
+	if re == nil {
+		re = defaultPriceRegex
+	}
+
+	var amounts []float64
+	for _, m := range re.FindAllString(text, -1) {
+		m = strings.ReplaceAll(m, ",", ".")
+		p, err := strconv.ParseFloat(m, 64)
+		if err != nil {
+			continue
+		}
+		amounts = append(amounts, p)
+	}
+
+	return amounts
+}
+
+// parsePrice разбирает текст цены: наименьшее и наибольшее число дают диапазон «от — до»,
+// валюта берётся по символу или коду в тексте (иначе currency), а слова вроде «gratis»,
+// «taquilla inversa» или «agotadas» отмечают бесплатный вход, вход за пожертвование и
+// распроданные билеты. Второе значение false, если в тексте нет ни чисел, ни таких слов.
+func parsePrice(text string, re *regexp.Regexp, currency string) (domain.Price, bool) {
+	price := domain.Price{Currency: currency}
+	if detected := detectCurrency(text); detected != "" {
+		price.Currency = detected
+	}
+
+	words := priceWords(text)
+	price.Free = containsAnyWord(words, freeWords)
+	price.Donation = containsAnyWord(words, donationWords)
+	if containsAnyWord(words, soldOutWords) {
+		price.Availability = domain.TicketAvailabilitySoldOut
+	}
+
+	amounts := parseAmounts(text, re)
+	for i, amount := range amounts {
+		if i == 0 || amount < price.Min {
+			price.Min = amount
+		}
+		if amount > price.Max {
+			price.Max = amount
+		}
+	}
+	// Цена "0 €" — тоже бесплатный вход
+	if len(amounts) > 0 && price.Max == 0 && !price.Donation {
+		price.Free = true
+	}
+
+	price.Normalize()
+
+	found := len(amounts) > 0 || price.Free || price.Donation || price.Availability != domain.TicketAvailabilityUnknown
+	return price, found
+}
+
+// Слова в тексте цены на поддерживаемых языках. Сравниваются с началом слов текста в нижнем регистре,
+// поэтому окончания можно опускать; пробел в конце требует совпадения слова целиком.
+var (
+	freeWords = []string{
+		"free ", "gratis", "gratuit", "gratuït", "entrada libre", "entrada lliure",
+		"бесплатн", "вход свободный", "kostenlos", "eintritt frei", "entrée libre", "ingresso libero",
+	}
+	donationWords = []string{
+		"donation", "donativo", "donatiu", "donació", "taquilla inversa", "la voluntad",
+		"pay what you", "пожертвован", "донат", "spende", "don libre", "offerta libera", "doação",
+	}
+	soldOutWords = []string{
+		"sold out", "soldout", "agotad", "esgotad", "распродан", "ausverkauft",
+		"complet ", "esaurit",
+	}
+)
+
+// currencySymbols — символы и сокращения валют в тексте цены и их коды.
+var currencySymbols = map[string]string{
+	"€": "EUR", "$": "USD", "£": "GBP", "₽": "RUB", "руб": "RUB", "₺": "TRY", "₾": "GEL", "zł": "PLN",
+}
+
+// currencyCodeRegex — выражение для поиска трёхбуквенного кода валюты в тексте цены.
+var currencyCodeRegex = regexp.MustCompile(`\b(?:EUR|USD|GBP|RUB|CHF|PLN|CZK|SEK|NOK|DKK|TRY|GEL)\b`)
+
+// detectCurrency возвращает код валюты, указанной в тексте цены кодом или символом,
+// или пустую строку, если валюта не указана.
+func detectCurrency(text string) string {
+	if code := currencyCodeRegex.FindString(strings.ToUpper(text)); code != "" {
+		return code
+	}
+
+	first, currency := -1, ""
+	for symbol, code := range currencySymbols {
+		if i := strings.Index(strings.ToLower(text), symbol); i >= 0 && (first < 0 || i < first) {
+			first, currency = i, code
+		}
+	}
+	return currency
+}
+
+// priceWords возвращает слова текста цены в нижнем регистре через пробел, с пробелами по краям.
+func priceWords(text string) string {
+	words := wordRegex.FindAllString(strings.ToLower(text), -1)
+	for i, word := range words {
+		words[i] = strings.TrimSuffix(word, ".")
+	}
+	return " " + strings.Join(words, " ") + " "
+}
+
+// containsAnyWord проверяет, начинается ли с одного из keywords какое-либо слово в words (см. priceWords).
+func containsAnyWord(words string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(words, " "+keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// translateMonths заменяет названия месяцев языка lang на английские сокращения ("Jan", "Feb", ...),
+// чтобы дату можно было разобрать стандартными форматами Go.
+// Для пустого или неизвестного языка строка возвращается без изменений.
+func translateMonths(value string, lang string) string {
+	months, ok := monthNames[strings.ToLower(lang)]
+	if !ok {
+		return value
+	}
+
+	return wordRegex.ReplaceAllStringFunc(value, func(word string) string {
+		if month, ok := months[strings.ToLower(strings.TrimSuffix(word, "."))]; ok {
+			return month.String()[:3]
+		}
+		return word
+	})
+}
+
+// parseDate пробует разобрать дату по каждому из форматов по очереди.
+// Даты без смещения считаются заданными в часовом поясе loc.
+func parseDate(value string, layouts []string, loc *time.Location) (time.Time, bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isDateOnly проверяет, что значение — дата без времени в формате ISO 8601 ("2006-01-02").
+// Такие даты в JSON-LD, iCalendar и API означают события на целые дни.
+func isDateOnly(value string) bool {
+	_, err := time.Parse(time.DateOnly, strings.TrimSpace(value))
+	return err == nil
+}
+
+// parseClocks разбирает время начала и окончания из текста clock: сначала целиком по форматам layouts,
+// затем по первым двум найденным значениям времени (например, "20:00" и "23:00" из "20:00 - 23:00").
+func parseClocks(clock string, layouts []string) []time.Time {
+	if t, ok := parseDate(clock, layouts, time.UTC); ok {
+		return []time.Time{t}
+	}
+
+	var clocks []time.Time
+	for _, match := range clockRegex.FindAllString(clock, 2) {
+		if t, ok := parseDate(match, layouts, time.UTC); ok {
+			clocks = append(clocks, t)
+		}
+	}
+	return clocks
+}
+
+// atClock переносит часы и минуты из clock в дату date.
+func atClock(date time.Time, clock time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, date.Location())
+}
+
+// uniqueStrings удаляет дубликаты из slice строк
+func uniqueStrings(input []string) []string {
+	seen := make(map[string]bool)
+	result := []string{}
+	for _, v := range input {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// youtubeWatchURL превращает ссылку на embed-плеер YouTube в обычную ссылку на видео.
+func youtubeWatchURL(src string) string {
+	src = strings.ReplaceAll(src, "embed/", "watch?v=")
+	src = strings.ReplaceAll(src, "?feature=oembed", "")
+	return src
+}
@@ -0,0 +1,49 @@
+package sites
+
+import (
+	"regexp"
+	"testing"
+
+	"eventsBot/internal/models/domain"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		text  string
+		re    *regexp.Regexp
+		want  domain.Price
+		found bool
+	}{
+		{text: "15€", want: domain.Price{Min: 15, Currency: "EUR"}, found: true},
+		{text: "12,50 €", want: domain.Price{Min: 12.5, Currency: "EUR"}, found: true},
+		{text: "from 10 to 25", want: domain.Price{Min: 10, Max: 25, Currency: "EUR"}, found: true},
+		{text: "desde 25€ hasta 10€", want: domain.Price{Min: 10, Max: 25, Currency: "EUR"}, found: true},
+		{text: "Anticipada 8 / Taquilla 8", want: domain.Price{Min: 8, Currency: "EUR"}, found: true},
+		{text: "$20 - $35", want: domain.Price{Min: 20, Max: 35, Currency: "USD"}, found: true},
+		{text: "Tickets: 30 GBP", want: domain.Price{Min: 30, Currency: "GBP"}, found: true},
+		{text: "Free", want: domain.Price{Currency: "EUR", Free: true}, found: true},
+		{text: "Entrada libre hasta completar aforo", want: domain.Price{Currency: "EUR", Free: true}, found: true},
+		{text: "Вход бесплатный", want: domain.Price{Currency: "EUR", Free: true}, found: true},
+		{text: "0 €", want: domain.Price{Currency: "EUR", Free: true}, found: true},
+		// Часть мест бесплатна, остальные платные — событие не бесплатное
+		{text: "Gratis para socios, 5€ resto", want: domain.Price{Min: 5, Currency: "EUR"}, found: true},
+		// "free" — только целое слово
+		{text: "Freedom tour 20€", want: domain.Price{Min: 20, Currency: "EUR"}, found: true},
+		{text: "Taquilla inversa", want: domain.Price{Currency: "EUR", Donation: true}, found: true},
+		{text: "Donativo 0 €", want: domain.Price{Currency: "EUR", Donation: true}, found: true},
+		{text: "Donativo desde 5€", want: domain.Price{Min: 5, Currency: "EUR", Donation: true}, found: true},
+		{text: "Entradas agotadas", want: domain.Price{Currency: "EUR", Availability: domain.TicketAvailabilitySoldOut}, found: true},
+		{text: "SOLD OUT - 18€", want: domain.Price{Min: 18, Currency: "EUR", Availability: domain.TicketAvailabilitySoldOut}, found: true},
+		{text: "Consultar", want: domain.Price{Currency: "EUR"}, found: false},
+		{text: "", want: domain.Price{Currency: "EUR"}, found: false},
+		// Своё выражение сайта пропускает номер зала
+		{text: "Sala 2 · 14 eur", re: regexp.MustCompile(`\d{2,}`), want: domain.Price{Min: 14, Currency: "EUR"}, found: true},
+	}
+
+	for _, tt := range tests {
+		got, found := parsePrice(tt.text, tt.re, "eur")
+		if got != tt.want || found != tt.found {
+			t.Errorf("parsePrice(%q) = %+v, %v; want %+v, %v", tt.text, got, found, tt.want, tt.found)
+		}
+	}
+}
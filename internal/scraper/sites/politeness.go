@@ -0,0 +1,220 @@
+package sites
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"eventsBot/internal/config"
+
+	"github.com/temoto/robotstxt"
+	"golang.org/x/time/rate"
+)
+
+// Значения по умолчанию для настроек вежливого обхода.
+const (
+	defaultRateLimit = 2.0
+	defaultUserAgent = "eventsBot/1.0"
+	defaultRobotsTTL = 24 * time.Hour
+	// robotsRetryTTL — через сколько повторить загрузку robots.txt, которую не удалось выполнить.
+	robotsRetryTTL = 10 * time.Minute
+	// maxRobotsSize ограничивает размер robots.txt.
+	maxRobotsSize int64 = 512 << 10
+)
+
+// BlockedError — URL запрещён для обхода правилами robots.txt сайта.
+type BlockedError struct {
+	URL string
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%s is disallowed by robots.txt", e.URL)
+}
+
+// domainLimits — ограничения частоты запросов к домену.
+type domainLimits struct {
+	rateLimit  float64       // Запросов в секунду
+	crawlDelay time.Duration // Минимальная пауза между запросами
+}
+
+// robotsEntry — закешированные правила robots.txt домена.
+type robotsEntry struct {
+	mu        sync.Mutex
+	group     *robotstxt.Group // nil — правила не загружены, обход разрешён
+	expiresAt time.Time
+}
+
+// politeness следит за вежливым обходом сайтов: соблюдает robots.txt,
+// ограничивает частоту запросов к каждому домену и представляется владельцам сайтов.
+type politeness struct {
+	userAgent string
+	contact   string
+	robotsTTL time.Duration
+	timeout   time.Duration // Таймаут загрузки robots.txt
+	defaults  domainLimits
+
+	mu       sync.Mutex
+	limits   map[string]domainLimits  // Ограничения сайтов из конфигурации по доменам
+	limiters map[string]*rate.Limiter // Ограничители частоты запросов по доменам
+	robots   map[string]*robotsEntry  // Кеш robots.txt по доменам
+}
+
+// newPoliteness создаёт politeness по настройкам из конфигурации.
+func newPoliteness(cfg config.FetchConfig, timeout time.Duration) *politeness {
+	p := &politeness{
+		userAgent: cfg.UserAgent,
+		contact:   cfg.Contact,
+		robotsTTL: cfg.RobotsTTL,
+		timeout:   timeout,
+		defaults:  domainLimits{rateLimit: cfg.RateLimit},
+		limits:    make(map[string]domainLimits),
+		limiters:  make(map[string]*rate.Limiter),
+		robots:    make(map[string]*robotsEntry),
+	}
+	if p.userAgent == "" {
+		p.userAgent = defaultUserAgent
+	}
+	if p.contact != "" {
+		p.userAgent = fmt.Sprintf("%s (+%s)", p.userAgent, p.contact)
+	}
+	if p.robotsTTL <= 0 {
+		p.robotsTTL = defaultRobotsTTL
+	}
+	if p.defaults.rateLimit <= 0 {
+		p.defaults.rateLimit = defaultRateLimit
+	}
+	return p
+}
+
+// setLimits задаёт ограничения частоты запросов к домену host.
+// Нулевые значения заменяются значениями по умолчанию.
+func (p *politeness) setLimits(host string, limits domainLimits) {
+	if limits.rateLimit <= 0 {
+		limits.rateLimit = p.defaults.rateLimit
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.limits[host] = limits
+	if limiter, ok := p.limiters[host]; ok {
+		limiter.SetLimit(rate.Every(limits.interval(0)))
+	}
+}
+
+// setHeaders добавляет в запрос User-Agent и контакт для владельцев сайта.
+func (p *politeness) setHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", p.userAgent)
+	if p.contact != "" {
+		req.Header.Set("From", p.contact)
+	}
+}
+
+// allow проверяет, что robots.txt разрешает обход u, и ждёт своей очереди
+// согласно ограничению частоты запросов к домену.
+func (p *politeness) allow(ctx context.Context, client *http.Client, u *url.URL) error {
+	group := p.robotsGroup(ctx, client, u)
+	if group != nil && !group.Test(u.RequestURI()) {
+		return &BlockedError{URL: u.String()}
+	}
+
+	var crawlDelay time.Duration
+	if group != nil {
+		crawlDelay = group.CrawlDelay
+	}
+	return p.limiter(u.Host, crawlDelay).Wait(ctx)
+}
+
+// limiter возвращает ограничитель частоты запросов к домену host с учётом Crawl-delay из robots.txt.
+func (p *politeness) limiter(host string, robotsDelay time.Duration) *rate.Limiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	limits, ok := p.limits[host]
+	if !ok {
+		limits = p.defaults
+	}
+	limit := rate.Every(limits.interval(robotsDelay))
+
+	limiter, ok := p.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(limit, 1)
+		p.limiters[host] = limiter
+	} else if limiter.Limit() != limit {
+		limiter.SetLimit(limit)
+	}
+	return limiter
+}
+
+// interval возвращает паузу между запросами: наибольшую из 1/rateLimit, crawlDelay и robotsDelay.
+func (l domainLimits) interval(robotsDelay time.Duration) time.Duration {
+	interval := time.Duration(float64(time.Second) / l.rateLimit)
+	return max(interval, l.crawlDelay, robotsDelay)
+}
+
+// robotsGroup возвращает правила robots.txt домена для нашего User-Agent,
+// при необходимости скачивая robots.txt. Если robots.txt скачать не удалось,
+// обход разрешается, а попытка повторяется через robotsRetryTTL.
+func (p *politeness) robotsGroup(ctx context.Context, client *http.Client, u *url.URL) *robotstxt.Group {
+	key := u.Scheme + "://" + u.Host
+
+	p.mu.Lock()
+	entry, ok := p.robots[key]
+	if !ok {
+		entry = &robotsEntry{}
+		p.robots[key] = entry
+	}
+	p.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if time.Now().Before(entry.expiresAt) {
+		return entry.group
+	}
+
+	robots, err := p.fetchRobots(ctx, client, key+"/robots.txt")
+	if err != nil {
+		entry.group = nil
+		entry.expiresAt = time.Now().Add(robotsRetryTTL)
+		return nil
+	}
+
+	entry.group = robots.FindGroup(p.userAgent)
+	entry.expiresAt = time.Now().Add(p.robotsTTL)
+	return entry.group
+}
+
+// fetchRobots скачивает и разбирает robots.txt. Ответы 4xx означают отсутствие ограничений,
+// 5xx — запрет обхода всего сайта.
+func (p *politeness) fetchRobots(ctx context.Context, client *http.Client, robotsURL string) (*robotstxt.RobotsData, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	p.setHeaders(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", robotsURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", robotsURL, err)
+	}
+
+	robots, err := robotstxt.FromStatusAndBytes(resp.StatusCode, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", robotsURL, err)
+	}
+	return robots, nil
+}
@@ -0,0 +1,72 @@
+package sites
+
+import (
+	"context"
+	"fmt"
+
+	"eventsBot/internal/config"
+)
+
+// builtinScrapers — скраперы, написанные под конкретные сайты. Используются для сайтов без типа.
+var builtinScrapers = map[string]func(site config.SiteConfig) (ScrapeFunc, error){
+	"lococlub": NewLococlubScraper,
+}
+
+// New создаёт скрапер для сайта по его конфигурации. Сайты без типа используют
+// скрапер, написанный под сайт с тем же именем.
+// Часовой пояс сайта (config.SiteConfig.TimezoneName) используется типами, в настройках которых он не задан.
+func New(site config.SiteConfig) (ScrapeFunc, error) {
+	site.Timezone = site.TimezoneName()
+	if site.Selectors.Timezone == "" {
+		site.Selectors.Timezone = site.Timezone
+	}
+	if site.ICS.Timezone == "" {
+		site.ICS.Timezone = site.Timezone
+	}
+	if site.API.Timezone == "" {
+		site.API.Timezone = site.Timezone
+	}
+
+	scrapeFunc, err := newScrapeFunc(site)
+	if err != nil {
+		return nil, err
+	}
+	return withNormalizedDates(scrapeFunc), nil
+}
+
+// newScrapeFunc создаёт скрапер сайта по типу из конфигурации.
+func newScrapeFunc(site config.SiteConfig) (ScrapeFunc, error) {
+	switch site.Type {
+	case "":
+		newScraper, ok := builtinScrapers[site.Name]
+		if !ok {
+			return nil, fmt.Errorf("no built-in scraper for site: %s", site.Name)
+		}
+		return newScraper(site)
+	case "selector":
+		return NewSelectorScraper(site.Selectors)
+	case "mec":
+		return NewMECScraper(site.Selectors)
+	case "jsonld":
+		return NewJSONLDScraper(site.Selectors)
+	case "feed":
+		return NewFeedScraper(site.Feed)
+	case "ics":
+		return NewICSScraper(site.ICS)
+	case "api":
+		return NewAPIScraper(site.API)
+	default:
+		return nil, fmt.Errorf("unknown scraper type: %s", site.Type)
+	}
+}
+
+// withNormalizedDates приводит даты событий, найденных скрапером, к единому виду (domain.Event.NormalizeDates).
+func withNormalizedDates(scrapeFunc ScrapeFunc) ScrapeFunc {
+	return func(ctx context.Context, req Request) (Result, error) {
+		result, err := scrapeFunc(ctx, req)
+		for i := range result.Events {
+			result.Events[i].NormalizeDates()
+		}
+		return result, err
+	}
+}
@@ -0,0 +1,222 @@
+package sites
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// selectorScraper — декларативный скрапер, работающий по CSS-селекторам из конфигурации.
+type selectorScraper struct {
+	cfg        config.SelectorConfig
+	priceRegex *regexp.Regexp
+	location   *time.Location // Часовой пояс дат на сайте
+}
+
+// NewSelectorScraper создаёт ScrapeFunc по описанию селекторов из конфигурации.
+// Возвращает ошибку, если не задан селектор ссылок, регулярное выражение цены
+// или часовой пояс некорректны.
+func NewSelectorScraper(cfg config.SelectorConfig) (ScrapeFunc, error) {
+	if cfg.ListItem == "" {
+		return nil, fmt.Errorf("selector scraper: listItem selector is empty")
+	}
+	if cfg.LinkAttr == "" {
+		cfg.LinkAttr = "href"
+	}
+	if cfg.PhotoAttr == "" {
+		cfg.PhotoAttr = "src"
+	}
+	if cfg.VideoAttr == "" {
+		cfg.VideoAttr = "src"
+	}
+	if len(cfg.DateLayouts) == 0 {
+		cfg.DateLayouts = []string{"02 Jan 2006", "2006-01-02"}
+	}
+	if len(cfg.TimeLayouts) == 0 {
+		cfg.TimeLayouts = []string{"15:04"}
+	}
+
+	s := &selectorScraper{cfg: cfg, location: time.UTC}
+
+	if cfg.PriceRegex != "" {
+		re, err := regexp.Compile(cfg.PriceRegex)
+		if err != nil {
+			return nil, fmt.Errorf("selector scraper: invalid priceRegex: %w", err)
+		}
+		s.priceRegex = re
+	}
+
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("selector scraper: invalid timezone: %w", err)
+		}
+		s.location = loc
+	}
+
+	return s.scrape, nil
+}
+
+// scrape собирает ссылки на события со страницы списка и разбирает страницы событий.
+// Страницы событий скачиваются параллельно, страницы известных событий пропускаются.
+func (s *selectorScraper) scrape(ctx context.Context, req Request) (Result, error) {
+	// 1. Собираем ссылки на все события
+	eventLinks, err := s.collectLinks(ctx, req)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// 2. Для каждой ссылки собираем детали
+	events, res, err := fetchPages(ctx, req, eventLinks, func(link string, doc *goquery.Document, pageURL *url.URL) (domain.Event, bool) {
+		event := s.parseDetails(doc, pageURL)
+		event.EventLink = link
+		event.Status = domain.EventStatusNew
+		// Страница без названия попадает в Result.Unparsed: так изменение разметки видно в проверке работоспособности
+		return event, event.Name != ""
+	})
+
+	res.Events = events
+	return res, err
+}
+
+// collectLinks возвращает уникальные абсолютные ссылки на события со страницы списка.
+func (s *selectorScraper) collectLinks(ctx context.Context, req Request) ([]string, error) {
+	doc, pageURL, err := req.Fetcher.FetchDocument(ctx, req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventLinks []string
+	doc.Find(s.cfg.ListItem).Each(func(i int, sel *goquery.Selection) {
+		href, ok := sel.Attr(s.cfg.LinkAttr)
+		if !ok {
+			return
+		}
+		absoluteURL, err := pageURL.Parse(strings.TrimSpace(href))
+		if err != nil {
+			return
+		}
+		eventLinks = append(eventLinks, absoluteURL.String())
+	})
+
+	return uniqueStrings(eventLinks), nil
+}
+
+// parseDetails разбирает страницу события по селекторам из конфигурации.
+func (s *selectorScraper) parseDetails(doc *goquery.Document, pageURL *url.URL) domain.Event {
+	var event domain.Event
+
+	// Название
+	if s.cfg.Name != "" {
+		event.Name = cleanText(doc.Find(s.cfg.Name).First().Text())
+	}
+
+	// Описание
+	if s.cfg.Description != "" {
+		descSelection := doc.Find(s.cfg.Description).Clone()
+		descSelection.Find("script, style").Remove()
+		event.Description = cleanText(descSelection.Text())
+	}
+
+	// Фото
+	if s.cfg.Photo != "" {
+		if src, ok := doc.Find(s.cfg.Photo).First().Attr(s.cfg.PhotoAttr); ok && src != "" {
+			event.Photo = resolveURL(pageURL, src)
+		}
+	}
+
+	// Дата и время
+	if s.cfg.Date != "" {
+		if t, ok := s.parseDate(doc, s.cfg.Date); ok {
+			s.setDates(doc, &event, t)
+		}
+	}
+
+	// Цена
+	if s.cfg.Price != "" {
+		priceText := doc.Find(s.cfg.Price).First().Text()
+		if price, ok := parsePrice(priceText, s.priceRegex, s.cfg.Currency); ok {
+			event.Price = price
+		}
+	}
+
+	// Место проведения; ссылку на карту по нему строит скрапер после поиска места в справочнике
+	if s.cfg.Venue != "" {
+		event.Venue.Name = cleanText(doc.Find(s.cfg.Venue).First().Text())
+	}
+	if s.cfg.Address != "" {
+		event.Venue.Address = cleanText(doc.Find(s.cfg.Address).First().Text())
+	}
+
+	// Ссылка на покупку
+	if s.cfg.BuyLink != "" {
+		if buyLink, ok := doc.Find(s.cfg.BuyLink).First().Attr("href"); ok && buyLink != "" {
+			event.Description += "\n\nКупить билет: " + resolveURL(pageURL, buyLink)
+		}
+	}
+
+	// Видео
+	if s.cfg.Video != "" {
+		if src, ok := doc.Find(s.cfg.Video).First().Attr(s.cfg.VideoAttr); ok && src != "" {
+			event.VideoURL = youtubeWatchURL(src)
+		}
+	}
+
+	// Данные schema.org дополняют поля, которые не удалось найти селекторами
+	if ld := extractJSONLDEvents(doc, pageURL, s.location); len(ld) > 0 {
+		ld[0].fillEmpty(&event)
+	}
+
+	return event
+}
+
+// parseDate разбирает дату из первого элемента по селектору selector.
+// Разделители диапазона по краям ("- 17 Mar") отбрасываются.
+func (s *selectorScraper) parseDate(doc *goquery.Document, selector string) (time.Time, bool) {
+	dateStr := strings.Trim(doc.Find(selector).First().Text(), " \t\n-–—")
+	dateStr = translateMonths(dateStr, s.cfg.DateLanguage)
+	return parseDate(dateStr, s.cfg.DateLayouts, s.location)
+}
+
+// setDates заполняет начало и окончание события с датой начала date. Окончание берётся из даты
+// окончания и второго времени в тексте времени ("20:00 - 23:00"). Событие без времени, как и
+// многодневное событие без времени окончания (например, выставка), считается событием на целые дни.
+func (s *selectorScraper) setDates(doc *goquery.Document, event *domain.Event, date time.Time) {
+	event.Date = date
+	event.AllDay = true
+
+	endDate := date
+	if s.cfg.EndDate != "" {
+		if t, ok := s.parseDate(doc, s.cfg.EndDate); ok {
+			endDate = t
+			event.EndDate = t
+		}
+	}
+
+	if s.cfg.Time == "" {
+		return
+	}
+	clocks := parseClocks(strings.TrimSpace(doc.Find(s.cfg.Time).First().Text()), s.cfg.TimeLayouts)
+	if len(clocks) == 0 || (len(clocks) == 1 && !endDate.Equal(date)) {
+		return
+	}
+
+	event.AllDay = false
+	event.Date = atClock(date, clocks[0])
+	event.EndDate = time.Time{}
+	if len(clocks) > 1 {
+		event.EndDate = atClock(endDate, clocks[1])
+		// Окончание не позже начала — событие заканчивается после полуночи
+		if !event.EndDate.After(event.Date) {
+			event.EndDate = event.EndDate.AddDate(0, 0, 1)
+		}
+	}
+}
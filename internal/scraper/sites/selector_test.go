@@ -0,0 +1,65 @@
+package sites
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"eventsBot/internal/config"
+)
+
+func TestSelectorScraperUnparsedPages(t *testing.T) {
+	pages := map[string]string{
+		"/events/": `<a class="event" href="/e/1">1</a><a class="event" href="/e/2">2</a><a class="event" href="/e/3">3</a><a class="event" href="/e/4">4</a>`,
+		"/e/1":     `<h1>Concert</h1>`,
+		"/e/2":     `<h2 class="renamed-title">Exhibition</h2>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/e/4" {
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+			return
+		}
+		body, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	scrapeFunc, err := NewSelectorScraper(config.SelectorConfig{ListItem: "a.event", Name: "h1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := scrapeFunc(ctx, Request{
+		URL:      server.URL + "/events/",
+		Shutdown: make(chan struct{}),
+		Fetcher:  NewFetcher(testFetchConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := eventNames(result.Events); !slices.Equal(got, []string{"Concert"}) {
+		t.Errorf("events = %v, want [Concert]", got)
+	}
+	// Страница без названия попадает в Unparsed, недоступная — в Failed, несуществующая страница — никуда
+	if want := []string{server.URL + "/e/2"}; !slices.Equal(result.Unparsed, want) {
+		t.Errorf("unparsed = %v, want %v", result.Unparsed, want)
+	}
+	if want := []string{server.URL + "/e/4"}; !slices.Equal(result.Failed, want) {
+		t.Errorf("failed = %v, want %v", result.Failed, want)
+	}
+	if result.FailedErr == nil {
+		t.Error("failed error is nil, want the fetch error of /e/4")
+	}
+}
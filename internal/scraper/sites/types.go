@@ -2,10 +2,55 @@ package sites
 
 import (
 	"context"
+	"fmt"
 
 	"eventsBot/internal/models/domain"
 )
 
+// Request — параметры одного запуска скрапера сайта.
+type Request struct {
+	URL      string          // URL для скрапинга
+	Shutdown <-chan struct{} // Канал остановки сервиса
+	Fetcher  *Fetcher        // Общий слой загрузки страниц
+	// IsKnown сообщает, что страница события по ссылке недавно проверялась и её можно не скачивать.
+	// Может быть nil — тогда скачиваются все страницы.
+	IsKnown func(ctx context.Context, link string) bool
+}
+
+// Result — результат запуска скрапера сайта.
+type Result struct {
+	Events  []domain.Event
+	Skipped []string // Ссылки на известные события, страницы которых не скачивались
+	Blocked []string // Ссылки, обход которых запрещён robots.txt
+	// Ссылки на скачанные страницы, из которых не удалось извлечь событие (например, без названия).
+	// Учитываются в проверке работоспособности скрапера как неполные события
+	Unparsed []string
+	// Ссылки на страницы событий, которые не удалось скачать (сетевые ошибки, таймауты, 5xx после повторов).
+	// События по ним остаются на сайте и не считаются пропавшими
+	Failed []string
+	// FailedErr — ошибки скачивания страниц из Failed, объединённые errors.Join
+	FailedErr error
+	// Truncated — выдача обрезана ограничением числа страниц: отсутствие события в ней не значит, что оно пропало
+	Truncated bool
+}
+
 // ScrapeFunc — тип функции скрапера для конкретного сайта.
-// Принимает контекст и URL, возвращает список событий.
-type ScrapeFunc func(ctx context.Context, url string, shutdownChan <-chan struct{}) ([]domain.Event, error)
+// Принимает контекст и параметры запуска, возвращает найденные события.
+type ScrapeFunc func(ctx context.Context, req Request) (Result, error)
+
+// isKnown проверяет ссылку через Request.IsKnown, если он задан.
+func (r Request) isKnown(ctx context.Context, link string) bool {
+	return r.IsKnown != nil && r.IsKnown(ctx, link)
+}
+
+// stopped возвращает ошибку, если контекст отменён или сервис останавливается.
+func (r Request) stopped(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-r.Shutdown:
+		return fmt.Errorf("shutdown")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,204 @@
+package telegramBot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"eventsBot/internal/models/domain"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// cancelPostsPrefix — префикс callback-данных кнопки пометки публикаций отменёнными.
+	cancelPostsPrefix = "cancelposts_"
+	// deletePostsPrefix — префикс callback-данных кнопки удаления публикаций.
+	deletePostsPrefix = "deleteposts_"
+)
+
+// NotifyEventCancelled сообщает админам об отменённом или пропавшем с сайта событии.
+// Если событие уже опубликовано, к сообщению добавляются кнопки для пометки публикаций
+// отменёнными и для их удаления.
+func (bot *Bot) NotifyEventCancelled(cancellation domain.EventCancellation, chatIDs []int64) error {
+	op := "bot.NotifyEventCancelled()"
+	event := cancellation.Event
+	log := bot.log.With(
+		slog.String("op", op),
+		slog.String("eventID", event.ID.String()),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	posts, err := bot.repository.FindEventPosts(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, chatID := range chatIDs {
+		// Дата показывается в часовом поясе чата
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "⚠️ <b>Событие отменено</b>\n\n<b>%s</b>\n", html.EscapeString(event.Name))
+		if !event.Date.IsZero() {
+			fmt.Fprintf(&sb, "📅 %s\n", event.FormatDates(bot.chatLocation(chatID)))
+		}
+		if event.EventLink != "" {
+			fmt.Fprintf(&sb, "🔗 <a href=\"%s\">Страница события</a>\n", event.EventLink)
+		}
+		fmt.Fprintf(&sb, "\nПричина: %s\n", html.EscapeString(cancellation.Reason))
+		fmt.Fprintf(&sb, "Публикаций в каналах: %d", len(posts))
+
+		msg := tgbotapi.NewMessage(chatID, sb.String())
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+		if len(posts) > 0 {
+			msg.ReplyMarkup = bot.createCancelledPostsKeyboard(event.ID.String())
+		}
+
+		if _, err := bot.tgbot.Send(msg); err != nil {
+			log.Error("failed to send cancellation notice",
+				slog.Int64("chatID", chatID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+// createCancelledPostsKeyboard создаёт inline keyboard для действий с публикациями отменённого события.
+func (bot *Bot) createCancelledPostsKeyboard(eventID string) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✏️ Пометить отменённым", cancelPostsPrefix+eventID),
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Удалить публикации", deletePostsPrefix+eventID),
+		),
+	)
+}
+
+// saveEventPost запоминает сообщение, в котором опубликовано событие.
+func (bot *Bot) saveEventPost(post domain.EventPost) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := bot.repository.SaveEventPost(ctx, post); err != nil {
+		bot.log.Error("failed to save event post",
+			slog.String("eventID", post.EventID.String()),
+			slog.Int64("chatID", post.ChatID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// handleCancelPosts дописывает пометку об отмене во все публикации события и убирает из них кнопки.
+func (bot *Bot) handleCancelPosts(callback *tgbotapi.CallbackQuery, eventID string) {
+	op := "bot.handleCancelPosts"
+	log := bot.log.With(
+		slog.String("op", op),
+		slog.String("eventID", eventID),
+	)
+
+	event, posts, ok := bot.loadCancelledPosts(callback, log, eventID)
+	if !ok {
+		return
+	}
+
+	empty := tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}
+
+	edited := 0
+	for _, post := range posts {
+		text := "❌ <b>ОТМЕНЕНО</b>\n\n" + bot.formatEventMessage(&event, bot.chatLocation(post.ChatID), nil, nil)
+
+		if err := bot.editPost(post, text, &empty); err != nil {
+			log.Error("failed to edit post",
+				slog.Int64("chatID", post.ChatID),
+				slog.Int("messageID", post.MessageID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		edited++
+	}
+
+	log.Info("posts marked as cancelled", slog.Int("edited", edited), slog.Int("total", len(posts)))
+	bot.sendCallbackResponse(callback, fmt.Sprintf("✏️ Отредактировано публикаций: %d из %d", edited, len(posts)))
+	bot.removeApprovalKeyboard(callback)
+}
+
+// handleDeletePosts удаляет все публикации события.
+func (bot *Bot) handleDeletePosts(callback *tgbotapi.CallbackQuery, eventID string) {
+	op := "bot.handleDeletePosts"
+	log := bot.log.With(
+		slog.String("op", op),
+		slog.String("eventID", eventID),
+	)
+
+	_, posts, ok := bot.loadCancelledPosts(callback, log, eventID)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	deleted := 0
+	for _, post := range posts {
+		if _, err := bot.tgbot.Request(tgbotapi.NewDeleteMessage(post.ChatID, post.MessageID)); err != nil {
+			log.Error("failed to delete post",
+				slog.Int64("chatID", post.ChatID),
+				slog.Int("messageID", post.MessageID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if err := bot.repository.DeleteEventPost(ctx, post.ChatID, post.MessageID); err != nil {
+			log.Error("failed to delete event post record", slog.String("error", err.Error()))
+		}
+		deleted++
+	}
+
+	log.Info("posts deleted", slog.Int("deleted", deleted), slog.Int("total", len(posts)))
+	bot.sendCallbackResponse(callback, fmt.Sprintf("🗑 Удалено публикаций: %d из %d", deleted, len(posts)))
+	bot.removeApprovalKeyboard(callback)
+}
+
+// loadCancelledPosts проверяет права пользователя и загружает событие и его публикации.
+// При ошибке отвечает на callback и возвращает false.
+func (bot *Bot) loadCancelledPosts(callback *tgbotapi.CallbackQuery, log *slog.Logger, eventID string) (domain.Event, []domain.EventPost, bool) {
+	if callback.From == nil || !slices.Contains(bot.cfg.BotConfig.Admins, callback.From.UserName) {
+		bot.sendCallbackResponse(callback, "⛔ Действие доступно только администраторам")
+		return domain.Event{}, nil, false
+	}
+
+	id, err := uuid.Parse(eventID)
+	if err != nil {
+		log.Error("failed to parse event ID", slog.String("error", err.Error()))
+		bot.sendCallbackResponse(callback, "❌ Некорректный идентификатор события")
+		return domain.Event{}, nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	event, err := bot.repository.FindEventByID(ctx, id)
+	if err != nil {
+		log.Error("failed to find event", slog.String("error", err.Error()))
+		bot.sendCallbackResponse(callback, "❌ Событие не найдено")
+		return domain.Event{}, nil, false
+	}
+
+	posts, err := bot.repository.FindEventPosts(ctx, id)
+	if err != nil {
+		log.Error("failed to find event posts", slog.String("error", err.Error()))
+		bot.sendCallbackResponse(callback, "❌ Ошибка при поиске публикаций")
+		return domain.Event{}, nil, false
+	}
+
+	return event, posts, true
+}
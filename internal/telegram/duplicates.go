@@ -0,0 +1,51 @@
+package telegramBot
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"eventsBot/internal/models/domain"
+)
+
+// eventDuplicates возвращает дубликаты события — то же мероприятие, найденное на других сайтах.
+func (bot *Bot) eventDuplicates(ctx context.Context, log *slog.Logger, event *domain.Event) []domain.Event {
+	duplicates, err := bot.repository.FindEventDuplicates(ctx, event.ID)
+	if err != nil {
+		log.Error("failed to find event duplicates", slog.String("error", err.Error()))
+		return nil
+	}
+	return duplicates
+}
+
+// formatDuplicateLinks форматирует ссылки на страницы дубликатов события в строку "Также на: ...".
+// Текст ссылки — домен сайта.
+func formatDuplicateLinks(duplicates []domain.Event) string {
+	links := make([]string, 0, len(duplicates))
+	seen := make(map[string]bool)
+	for _, d := range duplicates {
+		if d.EventLink == "" || seen[d.EventLink] {
+			continue
+		}
+		seen[d.EventLink] = true
+		links = append(links, fmt.Sprintf("<a href=\"%s\">%s</a>", d.EventLink, html.EscapeString(linkLabel(d))))
+	}
+	if len(links) == 0 {
+		return ""
+	}
+	return "🎟 <b>Также на:</b> " + strings.Join(links, ", ") + "\n"
+}
+
+// linkLabel возвращает домен страницы события без "www.", а если ссылку не удалось разобрать — имя сайта.
+func linkLabel(event domain.Event) string {
+	if u, err := url.Parse(event.EventLink); err == nil && u.Hostname() != "" {
+		return strings.TrimPrefix(u.Hostname(), "www.")
+	}
+	if event.SiteName != "" {
+		return event.SiteName
+	}
+	return "ссылка"
+}
@@ -2,6 +2,7 @@ package telegramBot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -506,6 +507,16 @@ func (bot *Bot) handleCallbackQuery(update *tgbotapi.Update) {
 		return
 	}
 
+	// Обработка действий с публикациями отменённых событий
+	if after, ok := strings.CutPrefix(data, cancelPostsPrefix); ok {
+		bot.handleCancelPosts(callback, after)
+		return
+	}
+	if after, ok := strings.CutPrefix(data, deletePostsPrefix); ok {
+		bot.handleDeletePosts(callback, after)
+		return
+	}
+
 	// Или отправить новое сообщение:
 	// msg := tgbotapi.NewMessage(chatID, responseText)
 	// _, _ = bot.tgbot.Send(msg)
@@ -520,25 +531,41 @@ func (bot *Bot) SendEvent(event *domain.Event, channelIDs []int64) error {
 		slog.String("eventName", event.Name),
 	)
 
-	// Формируем текст сообщения
-	messageText := bot.formatEventMessage(event)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Другая дата этого мероприятия уже опубликована — дописываем дату в её сообщения
+	if event.SeriesID != uuid.Nil {
+		posts, err := bot.repository.FindSeriesPosts(ctx, event.SeriesID)
+		if err != nil {
+			log.Error("failed to find series posts", slog.String("error", err.Error()))
+		} else if len(posts) > 0 {
+			return bot.refreshSeriesPosts(ctx, log, event, posts)
+		}
+	}
+	others := bot.otherSeriesEvents(ctx, log, event)
+	duplicates := bot.eventDuplicates(ctx, log, event)
 
 	for _, channelID := range channelIDs {
+		var sent tgbotapi.Message
 		var err error
 
-		// Если есть фото, отправляем с фото
-		if event.Photo != "" {
-			photo := tgbotapi.NewPhoto(channelID, tgbotapi.FileURL(event.Photo))
-			photo.Caption = messageText
-			photo.ParseMode = tgbotapi.ModeHTML
+		// Формируем текст сообщения: даты показываются в часовом поясе канала
+		messageText := bot.formatEventMessage(event, bot.chatLocation(channelID), others, duplicates)
 
-			// Добавляем inline keyboard для модерации
-			if event.Status == domain.EventStatusReadyToApprove {
-				photo.ReplyMarkup = bot.createApprovalKeyboard(event.ID.String())
+		// Если есть фото, отправляем с фото; если его не удалось отправить — публикуем без него
+		isPhoto := event.Photo != ""
+		if isPhoto {
+			sent, err = bot.sendEventPhoto(ctx, log, event, channelID, messageText)
+			if err != nil {
+				log.Warn("failed to send event with photo, sending text only",
+					slog.Int64("channelID", channelID),
+					slog.String("error", err.Error()),
+				)
+				isPhoto = false
 			}
-
-			_, err = bot.tgbot.Send(photo)
-		} else {
+		}
+		if !isPhoto {
 			// Если нет фото, отправляем текстовое сообщение
 			msg := tgbotapi.NewMessage(channelID, messageText)
 			msg.ParseMode = tgbotapi.ModeHTML
@@ -548,7 +575,7 @@ func (bot *Bot) SendEvent(event *domain.Event, channelIDs []int64) error {
 				msg.ReplyMarkup = bot.createApprovalKeyboard(event.ID.String())
 			}
 
-			_, err = bot.tgbot.Send(msg)
+			sent, err = bot.tgbot.Send(msg)
 		}
 
 		if err != nil {
@@ -560,13 +587,22 @@ func (bot *Bot) SendEvent(event *domain.Event, channelIDs []int64) error {
 		}
 
 		log.Debug("event sent to channel", slog.Int64("channelID", channelID))
+
+		// Запоминаем сообщение, чтобы его можно было отредактировать или удалить при отмене события
+		bot.saveEventPost(domain.EventPost{
+			EventID:   event.ID,
+			ChatID:    channelID,
+			MessageID: sent.MessageID,
+			IsPhoto:   isPhoto,
+		})
 	}
 
 	return nil
 }
 
-// formatEventMessage форматирует событие в HTML-текст для Telegram.
-func (bot *Bot) formatEventMessage(event *domain.Event) string {
+// formatEventMessage форматирует событие в HTML-текст для Telegram. Дата показывается в часовом поясе loc,
+// others — другие даты того же мероприятия, duplicates — то же мероприятие на других сайтах.
+func (bot *Bot) formatEventMessage(event *domain.Event, loc *time.Location, others []domain.Event, duplicates []domain.Event) string {
 	var sb strings.Builder
 
 	fmt.Fprintf(&sb, "<b>%s</b>\n\n", event.Name)
@@ -576,15 +612,21 @@ func (bot *Bot) formatEventMessage(event *domain.Event) string {
 	}
 
 	if !event.Date.IsZero() {
-		fmt.Fprintf(&sb, "📅 <b>Дата:</b> %s\n", event.Date.Format("02.01.2006 15:04"))
+		fmt.Fprintf(&sb, "📅 <b>Дата:</b> %s\n", event.FormatDates(loc))
+	}
+	sb.WriteString(formatSeriesDates(others, loc))
+
+	if price := event.Price.Format(); price != "" {
+		fmt.Fprintf(&sb, "💰 <b>Цена:</b> %s\n", price)
 	}
 
-	if event.Price > 0 {
-		fmt.Fprintf(&sb, "💰 <b>Цена:</b> %.0f %s\n", event.Price, event.Currency)
+	if hashtags := event.Hashtags(); hashtags != "" {
+		fmt.Fprintf(&sb, "🏷 %s\n", hashtags)
 	}
 
-	if event.Tag != "" {
-		fmt.Fprintf(&sb, "🏷 %s\n", event.Tag)
+	// Модератору показываем, что изменилось на сайте с прошлой публикации
+	if event.Status == domain.EventStatusReadyToApprove && len(event.ChangedFields) > 0 {
+		fmt.Fprintf(&sb, "✏️ <b>Изменено на сайте:</b> %s\n", strings.Join(event.ChangedFields, ", "))
 	}
 
 	fmt.Fprint(&sb, "\n")
@@ -592,6 +634,7 @@ func (bot *Bot) formatEventMessage(event *domain.Event) string {
 	if event.EventLink != "" {
 		fmt.Fprintf(&sb, "🔗 <a href=\"%s\">Подробнее</a>\n", event.EventLink)
 	}
+	sb.WriteString(formatDuplicateLinks(duplicates))
 
 	if event.MapLink != "" {
 		fmt.Fprintf(&sb, "📍 <a href=\"%s\">На карте</a>\n", event.MapLink)
@@ -611,8 +654,13 @@ func (bot *Bot) formatEventMessage(event *domain.Event) string {
 	// 	}
 	// }
 
-	if event.CalendarLinkAndroid != "" {
-		fmt.Fprintf(&sb, "  • <a href=\"%s\">📆 Добавить в календарь</a>\n", event.CalendarLinkAndroid)
+	// Ссылку на календарь строим по датам события, чтобы в ней была верная длительность
+	calendarLink := event.CalendarLink()
+	if calendarLink == "" {
+		calendarLink = event.CalendarLinkAndroid
+	}
+	if calendarLink != "" {
+		fmt.Fprintf(&sb, "  • <a href=\"%s\">📆 Добавить в календарь</a>\n", calendarLink)
 	}
 
 	return sb.String()
@@ -638,6 +686,7 @@ func (bot *Bot) handleApproveEvent(callback *tgbotapi.CallbackQuery, eventID str
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	ctx = domain.WithActor(ctx, callbackActor(callback))
 
 	id, err := uuid.Parse(eventID)
 	if err != nil {
@@ -646,13 +695,18 @@ func (bot *Bot) handleApproveEvent(callback *tgbotapi.CallbackQuery, eventID str
 		return
 	}
 
-	err = bot.repository.UpdateEventStatus(ctx, id, string(domain.EventStatusApproved))
+	err = bot.repository.UpdateEventStatus(ctx, id, string(domain.EventStatusApproved), "одобрено в Telegram")
 	if err != nil {
 		log.Error("failed to approve event", slog.String("error", err.Error()))
+		if bot.respondTransitionError(callback, err) {
+			return
+		}
 		bot.sendCallbackResponse(callback, "❌ Ошибка при одобрении события")
 		return
 	}
 
+	bot.updateSeriesStatus(ctx, log, id, domain.EventStatusApproved)
+
 	log.Info("event approved")
 	bot.sendCallbackResponse(callback, "✅ Событие одобрено")
 	bot.removeApprovalKeyboard(callback)
@@ -675,14 +729,20 @@ func (bot *Bot) handleDeclineEvent(callback *tgbotapi.CallbackQuery, eventID str
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	ctx = domain.WithActor(ctx, callbackActor(callback))
 
-	err = bot.repository.UpdateEventStatus(ctx, id, string(domain.EventStatusRejected))
+	err = bot.repository.UpdateEventStatus(ctx, id, string(domain.EventStatusRejected), "отклонено в Telegram")
 	if err != nil {
 		log.Error("failed to decline event", slog.String("error", err.Error()))
+		if bot.respondTransitionError(callback, err) {
+			return
+		}
 		bot.sendCallbackResponse(callback, "❌ Ошибка при отклонении события")
 		return
 	}
 
+	bot.updateSeriesStatus(ctx, log, id, domain.EventStatusRejected)
+
 	log.Info("event declined")
 	bot.sendCallbackResponse(callback, "❌ Событие отклонено")
 	bot.removeApprovalKeyboard(callback)
@@ -709,3 +769,30 @@ func (bot *Bot) removeApprovalKeyboard(callback *tgbotapi.CallbackQuery) {
 	)
 	_, _ = bot.tgbot.Send(editMsg)
 }
+
+// respondTransitionError сообщает модератору, что событие уже нельзя перевести в выбранный статус
+// (например, его уже отклонили через API или отменил скрапер), и убирает кнопки модерации.
+// Возвращает false, если err — не ошибка перехода статуса.
+func (bot *Bot) respondTransitionError(callback *tgbotapi.CallbackQuery, err error) bool {
+	var transitionErr *domain.TransitionError
+	if !errors.As(err, &transitionErr) {
+		return false
+	}
+	bot.sendCallbackResponse(callback, fmt.Sprintf("⚠️ Событие уже в статусе %s", transitionErr.From))
+	bot.removeApprovalKeyboard(callback)
+	return true
+}
+
+// callbackActor возвращает автора изменений события — пользователя, нажавшего кнопку.
+func callbackActor(callback *tgbotapi.CallbackQuery) domain.Actor {
+	actor := domain.Actor{Type: domain.ActorTelegram}
+	if callback.From == nil {
+		return actor
+	}
+	if callback.From.UserName != "" {
+		actor.ID = "@" + callback.From.UserName
+	} else {
+		actor.ID = strconv.FormatInt(callback.From.ID, 10)
+	}
+	return actor
+}
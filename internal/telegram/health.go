@@ -0,0 +1,65 @@
+package telegramBot
+
+import (
+	"fmt"
+	"html"
+	"log/slog"
+	"strings"
+
+	"eventsBot/internal/models/domain"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// NotifyScrapeHealth сообщает админам, что скрапер сайта сломался или восстановился.
+func (bot *Bot) NotifyScrapeHealth(alert domain.ScrapeRunAlert, chatIDs []int64) error {
+	op := "bot.NotifyScrapeHealth()"
+	run := alert.Run
+	log := bot.log.With(
+		slog.String("op", op),
+		slog.String("siteName", run.SiteName),
+	)
+
+	var sb strings.Builder
+	switch run.Status {
+	case domain.ScrapeRunStatusOK:
+		fmt.Fprintf(&sb, "✅ <b>Скрапер %s снова работает</b>\n\n", html.EscapeString(run.SiteName))
+	case domain.ScrapeRunStatusFailed:
+		fmt.Fprintf(&sb, "🛑 <b>Скрапер %s завершился с ошибкой</b>\n\n", html.EscapeString(run.SiteName))
+	default:
+		fmt.Fprintf(&sb, "⚠️ <b>Скрапер %s работает некорректно</b>\n\n", html.EscapeString(run.SiteName))
+	}
+
+	fmt.Fprintf(&sb, "Найдено событий: %d", run.EventsCount)
+	if run.SkippedCount > 0 || run.BlockedCount > 0 {
+		fmt.Fprintf(&sb, " (известных без загрузки: %d, запрещено robots.txt: %d)", run.SkippedCount, run.BlockedCount)
+	}
+	sb.WriteString("\n")
+	if run.IncompleteCount > 0 {
+		fmt.Fprintf(&sb, "Без обязательных полей: %d\n", run.IncompleteCount)
+	}
+	for _, problem := range run.Problems {
+		fmt.Fprintf(&sb, "• %s\n", html.EscapeString(problem))
+	}
+	if run.Error != "" {
+		fmt.Fprintf(&sb, "Ошибка: <code>%s</code>\n", html.EscapeString(run.Error))
+	}
+	if run.Status != domain.ScrapeRunStatusOK {
+		sb.WriteString("\nВозможно, изменилась вёрстка сайта — проверьте селекторы.")
+	}
+
+	for _, chatID := range chatIDs {
+		msg := tgbotapi.NewMessage(chatID, sb.String())
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.DisableWebPagePreview = true
+
+		if _, err := bot.tgbot.Send(msg); err != nil {
+			log.Error("failed to send scraper health alert",
+				slog.Int64("chatID", chatID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package telegramBot
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+
+	"eventsBot/internal/models/domain"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// photoSource — вариант фото события для отправки.
+type photoSource struct {
+	file   tgbotapi.RequestFileData
+	upload bool // Фото загружается из локальной копии: после отправки стоит запомнить его file_id
+}
+
+// photoSources возвращает варианты фото события в порядке предпочтения: file_id фото,
+// уже загруженного в Telegram, локальная копия и, в последнюю очередь, ссылка на сайт.
+func (bot *Bot) photoSources(ctx context.Context, log *slog.Logger, event *domain.Event) (domain.Image, []photoSource) {
+	var image domain.Image
+	var sources []photoSource
+
+	if event.ImageHash != "" {
+		var err error
+		image, err = bot.repository.FindImage(ctx, event.ImageHash)
+		if err != nil {
+			log.Error("failed to find event image", slog.String("error", err.Error()))
+		}
+	}
+
+	if image.TelegramFileID != "" {
+		sources = append(sources, photoSource{file: tgbotapi.FileID(image.TelegramFileID)})
+	}
+	if image.Path != "" {
+		sources = append(sources, photoSource{
+			file:   tgbotapi.FilePath(filepath.Join(bot.cfg.Images.Dir, image.Path)),
+			upload: true,
+		})
+	}
+	if event.Photo != "" {
+		sources = append(sources, photoSource{file: tgbotapi.FileURL(event.Photo)})
+	}
+	return image, sources
+}
+
+// sendEventPhoto отправляет событие в канал сообщением с фото, перебирая варианты фото
+// (см. photoSources), пока один из них не удастся отправить. После первой загрузки
+// локальной копии запоминает её file_id, чтобы при повторных публикациях не загружать фото снова.
+func (bot *Bot) sendEventPhoto(ctx context.Context, log *slog.Logger, event *domain.Event, channelID int64, text string) (tgbotapi.Message, error) {
+	image, sources := bot.photoSources(ctx, log, event)
+
+	var sent tgbotapi.Message
+	var err error
+	for _, source := range sources {
+		photo := tgbotapi.NewPhoto(channelID, source.file)
+		photo.Caption = text
+		photo.ParseMode = tgbotapi.ModeHTML
+
+		// Добавляем inline keyboard для модерации
+		if event.Status == domain.EventStatusReadyToApprove {
+			photo.ReplyMarkup = bot.createApprovalKeyboard(event.ID.String())
+		}
+
+		sent, err = bot.tgbot.Send(photo)
+		if err != nil {
+			log.Warn("failed to send event photo, trying next source",
+				slog.Int64("channelID", channelID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		if source.upload && len(sent.Photo) > 0 {
+			// Telegram возвращает несколько размеров фото, последний — самый большой
+			fileID := sent.Photo[len(sent.Photo)-1].FileID
+			if err := bot.repository.SetImageTelegramFileID(ctx, image.Hash, fileID); err != nil {
+				log.Error("failed to save telegram file id", slog.String("error", err.Error()))
+			}
+		}
+		return sent, nil
+	}
+
+	return sent, err
+}
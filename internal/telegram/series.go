@@ -0,0 +1,147 @@
+package telegramBot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"eventsBot/internal/models/domain"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
+)
+
+// maxSeriesDates — сколько других дат серии перечислять в сообщении о событии.
+const maxSeriesDates = 10
+
+// otherSeriesEvents возвращает предстоящие даты серии события, кроме него самого.
+func (bot *Bot) otherSeriesEvents(ctx context.Context, log *slog.Logger, event *domain.Event) []domain.Event {
+	if event.SeriesID == uuid.Nil {
+		return nil
+	}
+
+	events, err := bot.repository.FindSeriesEvents(ctx, event.SeriesID)
+	if err != nil {
+		log.Error("failed to find series events", slog.String("error", err.Error()))
+		return nil
+	}
+
+	others := make([]domain.Event, 0, len(events))
+	for _, e := range events {
+		if e.ID != event.ID {
+			others = append(others, e)
+		}
+	}
+	return others
+}
+
+// formatSeriesDates форматирует другие даты серии в строку "Также: ..." в часовом поясе loc.
+func formatSeriesDates(others []domain.Event, loc *time.Location) string {
+	if len(others) == 0 {
+		return ""
+	}
+
+	dates := make([]string, 0, min(len(others), maxSeriesDates))
+	for _, e := range others[:min(len(others), maxSeriesDates)] {
+		dates = append(dates, e.FormatDates(loc))
+	}
+
+	text := "🔁 <b>Также:</b> " + strings.Join(dates, ", ")
+	if len(others) > maxSeriesDates {
+		text += fmt.Sprintf(" и ещё %d", len(others)-maxSeriesDates)
+	}
+	return text + "\n"
+}
+
+// refreshSeriesPosts обновляет опубликованные сообщения серии, добавляя в них новую дату event,
+// вместо публикации почти одинакового сообщения. Если опубликованная дата уже одобрена,
+// event одобряется вместе с ней: содержимое у дат серии общее.
+func (bot *Bot) refreshSeriesPosts(ctx context.Context, log *slog.Logger, event *domain.Event, posts []domain.EventPost) error {
+	published := make(map[uuid.UUID]domain.Event)
+	approved := false
+
+	for _, post := range posts {
+		postedEvent, ok := published[post.EventID]
+		if !ok {
+			var err error
+			postedEvent, err = bot.repository.FindEventByID(ctx, post.EventID)
+			if err != nil {
+				log.Error("failed to find published series event", slog.String("error", err.Error()))
+				continue
+			}
+			published[post.EventID] = postedEvent
+		}
+		if postedEvent.Status == domain.EventStatusApproved {
+			approved = true
+		}
+
+		var markup tgbotapi.InlineKeyboardMarkup
+		if postedEvent.Status == domain.EventStatusReadyToApprove {
+			markup = bot.createApprovalKeyboard(postedEvent.ID.String())
+		} else {
+			markup = tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}
+		}
+
+		loc := bot.chatLocation(post.ChatID)
+		text := bot.formatEventMessage(&postedEvent, loc, bot.otherSeriesEvents(ctx, log, &postedEvent), bot.eventDuplicates(ctx, log, &postedEvent))
+		if err := bot.editPost(post, text, &markup); err != nil {
+			log.Error("failed to refresh series post",
+				slog.Int64("chatID", post.ChatID),
+				slog.Int("messageID", post.MessageID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	if approved && event.Status == domain.EventStatusReadyToApprove {
+		if err := bot.repository.UpdateEventStatus(ctx, event.ID, string(domain.EventStatusApproved), "дата уже одобренной серии"); err != nil {
+			return fmt.Errorf("failed to approve series event: %w", err)
+		}
+	}
+
+	log.Info("series posts refreshed with new date", slog.Int("posts", len(posts)), slog.Bool("approved", approved))
+	return nil
+}
+
+// updateSeriesStatus переводит в статус status даты серии события, ожидающие модерации:
+// они перечислены в том же сообщении и модерируются вместе с ним.
+func (bot *Bot) updateSeriesStatus(ctx context.Context, log *slog.Logger, eventID uuid.UUID, status domain.EventStatus) {
+	event, err := bot.repository.FindEventByID(ctx, eventID)
+	if err != nil {
+		log.Error("failed to find event", slog.String("error", err.Error()))
+		return
+	}
+
+	for _, other := range bot.otherSeriesEvents(ctx, log, &event) {
+		if other.Status != domain.EventStatusAIEnriched && other.Status != domain.EventStatusReadyToApprove {
+			continue
+		}
+		if err := bot.repository.UpdateEventStatus(ctx, other.ID, string(status), "модерация вместе с сообщением серии"); err != nil {
+			log.Error("failed to update series event status",
+				slog.String("seriesEventID", other.ID.String()),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// editPost заменяет текст (или подпись, если сообщение с фото) и клавиатуру опубликованного сообщения.
+func (bot *Bot) editPost(post domain.EventPost, text string, markup *tgbotapi.InlineKeyboardMarkup) error {
+	var edit tgbotapi.Chattable
+	if post.IsPhoto {
+		caption := tgbotapi.NewEditMessageCaption(post.ChatID, post.MessageID, text)
+		caption.ParseMode = tgbotapi.ModeHTML
+		caption.ReplyMarkup = markup
+		edit = caption
+	} else {
+		msg := tgbotapi.NewEditMessageText(post.ChatID, post.MessageID, text)
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyMarkup = markup
+		edit = msg
+	}
+
+	_, err := bot.tgbot.Send(edit)
+	return err
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 	"unicode/utf16"
 
 	"eventsBot/internal/config"
+	"eventsBot/internal/models/domain"
 	"eventsBot/internal/utils/logger/sl"
 
 	"log/slog"
@@ -21,7 +23,16 @@ type AIBotApi interface {
 
 // Repository определяет интерфейс для взаимодействия с хранилищем событий.
 type Repository interface {
-	UpdateEventStatus(ctx context.Context, eventID uuid.UUID, status string) error
+	UpdateEventStatus(ctx context.Context, eventID uuid.UUID, status string, reason string) error
+	FindEventByID(ctx context.Context, id uuid.UUID) (domain.Event, error)
+	SaveEventPost(ctx context.Context, post domain.EventPost) error
+	FindEventPosts(ctx context.Context, eventID uuid.UUID) ([]domain.EventPost, error)
+	DeleteEventPost(ctx context.Context, chatID int64, messageID int) error
+	FindSeriesEvents(ctx context.Context, seriesID uuid.UUID) ([]domain.Event, error)
+	FindSeriesPosts(ctx context.Context, seriesID uuid.UUID) ([]domain.EventPost, error)
+	FindEventDuplicates(ctx context.Context, canonicalID uuid.UUID) ([]domain.Event, error)
+	FindImage(ctx context.Context, hash string) (domain.Image, error)
+	SetImageTelegramFileID(ctx context.Context, hash string, fileID string) error
 }
 
 type Bot struct {
@@ -34,6 +45,8 @@ type Bot struct {
 	cancel          context.CancelFunc
 	log             *slog.Logger
 	UsersState      map[int64]UserState
+	location        *time.Location           // Часовой пояс дат в сообщениях по умолчанию
+	chatLocations   map[int64]*time.Location // Часовые пояса отдельных каналов и чатов
 }
 
 // UserState хранит состояние пользователя
@@ -66,6 +79,8 @@ func New(logger *slog.Logger, cfg *config.Config, repository Repository) *Bot {
 
 	log.Info("Authorized on account", slog.String("UserName", bot.Self.UserName))
 
+	location, chatLocations := loadLocations(log, cfg.BotConfig)
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Bot{
@@ -77,7 +92,50 @@ func New(logger *slog.Logger, cfg *config.Config, repository Repository) *Bot {
 		cancel:          cancel,
 		log:             log,
 		UsersState:      make(map[int64]UserState),
+		location:        location,
+		chatLocations:   chatLocations,
+	}
+}
+
+// loadLocations загружает часовой пояс сообщений по умолчанию и часовые пояса отдельных чатов.
+// Некорректные часовые пояса логируются и заменяются: по умолчанию — на UTC, для чатов — на пояс по умолчанию.
+func loadLocations(log *slog.Logger, cfg config.BotConfig) (*time.Location, map[int64]*time.Location) {
+	location := time.UTC
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			log.Error("failed to load bot timezone, using UTC",
+				slog.String("timezone", cfg.Timezone),
+				slog.String("error", err.Error()),
+			)
+		} else {
+			location = loc
+		}
+	}
+
+	chatLocations := make(map[int64]*time.Location, len(cfg.ChannelTimezones))
+	for chatID, timezone := range cfg.ChannelTimezones {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			log.Error("failed to load chat timezone, using default",
+				slog.Int64("chatID", chatID),
+				slog.String("timezone", timezone),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		chatLocations[chatID] = loc
+	}
+
+	return location, chatLocations
+}
+
+// chatLocation возвращает часовой пояс, в котором показываются даты в чате chatID.
+func (bot *Bot) chatLocation(chatID int64) *time.Location {
+	if loc, ok := bot.chatLocations[chatID]; ok {
+		return loc
 	}
+	return bot.location
 }
 
 func (bot *Bot) Start(updateTimeout int) {
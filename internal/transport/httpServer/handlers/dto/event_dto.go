@@ -10,42 +10,63 @@ import (
 
 // EventResponse — DTO для ответа с данными события.
 type EventResponse struct {
-	ID                  uuid.UUID `json:"id"`
-	Name                string    `json:"name"`
-	Photo               string    `json:"photo"`
-	Description         string    `json:"description"`
-	Date                time.Time `json:"date"`
-	Price               float64   `json:"price"`
-	Currency            string    `json:"currency"`
-	EventLink           string    `json:"event_link"`
-	MapLink             string    `json:"map_link"`
-	VideoURL            string    `json:"video_url"`
-	CalendarLinkIOS     string    `json:"calendar_link_ios"`
-	CalendarLinkAndroid string    `json:"calendar_link_android"`
-	Tag                 string    `json:"tag"`
-	Status              string    `json:"status"`
+	ID                  uuid.UUID  `json:"id"`
+	Name                string     `json:"name"`
+	Photo               string     `json:"photo"`
+	Description         string     `json:"description"`
+	Date                time.Time  `json:"date"`
+	EndDate             *time.Time `json:"end_date"` // Окончание события; null, если неизвестно
+	AllDay              bool       `json:"all_day"`  // Событие на целые дни: важны только даты в UTC
+	Price               PriceDTO   `json:"price"`
+	EventLink           string     `json:"event_link"`
+	MapLink             string     `json:"map_link"`
+	VideoURL            string     `json:"video_url"`
+	CalendarLinkIOS     string     `json:"calendar_link_ios"`
+	CalendarLinkAndroid string     `json:"calendar_link_android"`
+	Category            string     `json:"category"` // Категория из контролируемого списка (domain.Categories)
+	Tags                []string   `json:"tags"`     // Нормализованные теги
+	Status              string     `json:"status"`
+	SeriesID            *uuid.UUID `json:"series_id"`                 // Серия дат того же мероприятия; null, если серии нет
+	VenueID             *uuid.UUID `json:"venue_id"`                  // Место проведения; null, если неизвестно
+	CanonicalID         *uuid.UUID `json:"canonical_id"`              // Каноническое событие, дубликатом которого является это; null, если событие не дубликат
+	DuplicateScore      float64    `json:"duplicate_score,omitempty"` // Оценка сходства с каноническим событием (0–1)
+	ChangedFields       []string   `json:"changed_fields"`
 }
 
 // ChangeEventRequest — DTO для запроса на полное обновление события.
 type ChangeEventRequest struct {
-	Name                string    `json:"name"`
-	Photo               string    `json:"photo"`
-	Description         string    `json:"description"`
-	Date                time.Time `json:"date"`
-	Price               float64   `json:"price"`
-	Currency            string    `json:"currency"`
-	EventLink           string    `json:"event_link"`
-	MapLink             string    `json:"map_link"`
-	VideoURL            string    `json:"video_url"`
-	CalendarLinkIOS     string    `json:"calendar_link_ios"`
-	CalendarLinkAndroid string    `json:"calendar_link_android"`
-	Tag                 string    `json:"tag"`
-	Status              string    `json:"status"`
+	Name                string     `json:"name"`
+	Photo               string     `json:"photo"`
+	Description         string     `json:"description"`
+	Date                time.Time  `json:"date"`
+	EndDate             *time.Time `json:"end_date"`
+	AllDay              bool       `json:"all_day"`
+	Price               PriceDTO   `json:"price"`
+	EventLink           string     `json:"event_link"`
+	MapLink             string     `json:"map_link"`
+	VideoURL            string     `json:"video_url"`
+	CalendarLinkIOS     string     `json:"calendar_link_ios"`
+	CalendarLinkAndroid string     `json:"calendar_link_android"`
+	Category            string     `json:"category"` // Код или название категории; пустая — без категории
+	Tags                []string   `json:"tags"`     // Нормализуются и заменяются по справочнику синонимов
+	Status              string     `json:"status"`
+}
+
+// PriceDTO — DTO структурированной цены события.
+type PriceDTO struct {
+	Min          float64 `json:"min"`            // Минимальная цена; 0, если неизвестна
+	Max          float64 `json:"max"`            // Максимальная цена; 0, если цена одна
+	Currency     string  `json:"currency"`       // Код валюты
+	Free         bool    `json:"free"`           // Вход свободный
+	Donation     bool    `json:"donation"`       // Вход за пожертвование
+	Availability string  `json:"availability"`   // Наличие билетов: "", "AVAILABLE" или "SOLD_OUT"
+	Text         string  `json:"text,omitempty"` // Цена для показа пользователю; только в ответах
 }
 
 // UpdateStatusRequest — DTO для запроса на изменение статуса события.
 type UpdateStatusRequest struct {
 	Status string `json:"status"`
+	Reason string `json:"reason"` // Причина для истории статусов; необязательна
 }
 
 // MapDomainToEventResponse конвертирует доменную модель Event в EventResponse DTO.
@@ -56,15 +77,22 @@ func MapDomainToEventResponse(e domain.Event) EventResponse {
 		Photo:               e.Photo,
 		Description:         e.Description,
 		Date:                e.Date,
-		Price:               e.Price,
-		Currency:            e.Currency,
+		EndDate:             optionalTime(e.EndDate),
+		AllDay:              e.AllDay,
+		Price:               mapPriceToDTO(e.Price),
 		EventLink:           e.EventLink,
 		MapLink:             e.MapLink,
 		VideoURL:            e.VideoURL,
 		CalendarLinkIOS:     e.CalendarLinkIOS,
 		CalendarLinkAndroid: e.CalendarLinkAndroid,
-		Tag:                 e.Tag,
+		Category:            string(e.Category),
+		Tags:                e.Tags,
 		Status:              string(e.Status),
+		SeriesID:            optionalUUID(e.SeriesID),
+		VenueID:             optionalUUID(e.VenueID),
+		CanonicalID:         optionalUUID(e.CanonicalID),
+		DuplicateScore:      e.DuplicateScore,
+		ChangedFields:       e.ChangedFields,
 	}
 }
 
@@ -79,20 +107,78 @@ func MapDomainToEventResponseList(events []domain.Event) []EventResponse {
 
 // MapEventRequestToDomain конвертирует ChangeEventRequest DTO в доменную модель Event.
 func MapEventRequestToDomain(req ChangeEventRequest, id uuid.UUID) domain.Event {
-	return domain.Event{
+	event := domain.Event{
 		ID:                  id,
 		Name:                req.Name,
 		Photo:               req.Photo,
 		Description:         req.Description,
 		Date:                req.Date,
-		Price:               req.Price,
-		Currency:            req.Currency,
+		AllDay:              req.AllDay,
+		Price:               mapPriceToDomain(req.Price),
 		EventLink:           req.EventLink,
 		MapLink:             req.MapLink,
 		VideoURL:            req.VideoURL,
 		CalendarLinkIOS:     req.CalendarLinkIOS,
 		CalendarLinkAndroid: req.CalendarLinkAndroid,
-		Tag:                 req.Tag,
+		Tags:                req.Tags,
 		Status:              domain.EventStatus(req.Status),
 	}
+	if category, ok := domain.ParseCategory(req.Category); ok {
+		event.Category = category
+	}
+	if req.EndDate != nil {
+		event.EndDate = *req.EndDate
+	}
+	event.NormalizeDates()
+	return event
+}
+
+func mapPriceToDTO(p domain.Price) PriceDTO {
+	return PriceDTO{
+		Min:          p.Min,
+		Max:          p.Max,
+		Currency:     p.Currency,
+		Free:         p.Free,
+		Donation:     p.Donation,
+		Availability: string(p.Availability),
+		Text:         p.Format(),
+	}
+}
+
+func mapPriceToDomain(p PriceDTO) domain.Price {
+	price := domain.Price{
+		Min:          p.Min,
+		Max:          p.Max,
+		Currency:     p.Currency,
+		Free:         p.Free,
+		Donation:     p.Donation,
+		Availability: domain.TicketAvailability(p.Availability),
+	}
+	price.Normalize()
+	return price
+}
+
+// IsValidAvailability проверяет, что наличие билетов — одно из известных значений.
+func (p PriceDTO) IsValidAvailability() bool {
+	switch domain.TicketAvailability(p.Availability) {
+	case domain.TicketAvailabilityUnknown, domain.TicketAvailabilityAvailable, domain.TicketAvailabilitySoldOut:
+		return true
+	}
+	return false
+}
+
+// optionalTime возвращает nil для нулевого времени, чтобы в JSON оно было null.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// optionalUUID возвращает nil для uuid.Nil, чтобы в JSON он был null.
+func optionalUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
 }
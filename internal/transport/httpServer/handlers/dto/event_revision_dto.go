@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"eventsBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+// EventRevisionResponse — DTO для ответа с ревизией события.
+type EventRevisionResponse struct {
+	ID         uuid.UUID                      `json:"id"`
+	Action     string                         `json:"action"`
+	ActorType  string                         `json:"actor_type"`
+	ActorID    string                         `json:"actor_id,omitempty"`
+	Changes    map[string]FieldChangeResponse `json:"changes"`
+	RevertedTo *uuid.UUID                     `json:"reverted_to,omitempty"`
+	CreatedAt  time.Time                      `json:"created_at"`
+}
+
+// FieldChangeResponse — значения поля до и после изменения; null — значение отсутствовало.
+type FieldChangeResponse struct {
+	Old *string `json:"old"`
+	New *string `json:"new"`
+}
+
+// RevertEventRequest — DTO для отката события к ревизии.
+type RevertEventRequest struct {
+	RevisionID uuid.UUID `json:"revision_id"`
+}
+
+// MapRevisionToResponse конвертирует доменную модель ревизии события в DTO.
+func MapRevisionToResponse(rev domain.EventRevision) EventRevisionResponse {
+	changes := make(map[string]FieldChangeResponse, len(rev.Changes))
+	for field, change := range rev.Changes {
+		changes[field] = FieldChangeResponse{Old: change.Old, New: change.New}
+	}
+
+	var revertedTo *uuid.UUID
+	if rev.RevertedTo != uuid.Nil {
+		revertedTo = &rev.RevertedTo
+	}
+
+	return EventRevisionResponse{
+		ID:         rev.ID,
+		Action:     string(rev.Action),
+		ActorType:  string(rev.Actor.Type),
+		ActorID:    rev.Actor.ID,
+		Changes:    changes,
+		RevertedTo: revertedTo,
+		CreatedAt:  rev.CreatedAt,
+	}
+}
+
+// MapRevisionsToResponse конвертирует слайс ревизий события в слайс DTO.
+func MapRevisionsToResponse(revisions []domain.EventRevision) []EventRevisionResponse {
+	result := make([]EventRevisionResponse, len(revisions))
+	for i, rev := range revisions {
+		result[i] = MapRevisionToResponse(rev)
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"eventsBot/internal/scheduler"
+)
+
+// ScheduleEntryResponse — DTO для ответа с расписанием скрапинга сайта.
+type ScheduleEntryResponse struct {
+	Site      string     `json:"site"`
+	Schedule  string     `json:"schedule"`
+	NextRun   *time.Time `json:"next_run"`
+	LastRun   *time.Time `json:"last_run"`
+	LastError string     `json:"last_error,omitempty"`
+	Running   bool       `json:"running"`
+	Skipped   int        `json:"skipped"`
+}
+
+// MapScheduleEntriesToResponse конвертирует состояние планировщика в слайс DTO.
+func MapScheduleEntriesToResponse(entries []scheduler.EntryStatus) []ScheduleEntryResponse {
+	result := make([]ScheduleEntryResponse, len(entries))
+	for i, e := range entries {
+		result[i] = ScheduleEntryResponse{
+			Site:      e.Name,
+			Schedule:  e.Spec,
+			NextRun:   timeOrNil(e.NextRun),
+			LastRun:   timeOrNil(e.LastRun),
+			LastError: e.LastError,
+			Running:   e.Running,
+			Skipped:   e.Skipped,
+		}
+	}
+	return result
+}
+
+// timeOrNil возвращает nil для нулевого времени, чтобы в JSON оно было null.
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"eventsBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+// ScrapeRunResponse — DTO для ответа с запуском скрапера.
+type ScrapeRunResponse struct {
+	RequestID       uuid.UUID `json:"request_id"`
+	Site            string    `json:"site"`
+	Status          string    `json:"status"`
+	StartedAt       time.Time `json:"started_at"`
+	FinishedAt      time.Time `json:"finished_at"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	EventsCount     int       `json:"events_count"`
+	NewCount        int       `json:"new_count"`
+	ChangedCount    int       `json:"changed_count"`
+	DuplicateCount  int       `json:"duplicate_count"`
+	FailedCount     int       `json:"failed_count"`
+	IncompleteCount int       `json:"incomplete_count"`
+	SkippedCount    int       `json:"skipped_count"`
+	BlockedCount    int       `json:"blocked_count"`
+	Problems        []string  `json:"problems"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// MapScrapeRunToResponse конвертирует доменную модель запуска скрапера в DTO.
+func MapScrapeRunToResponse(run domain.ScrapeRun) ScrapeRunResponse {
+	problems := run.Problems
+	if problems == nil {
+		problems = []string{}
+	}
+	return ScrapeRunResponse{
+		RequestID:       run.RequestID,
+		Site:            run.SiteName,
+		Status:          string(run.Status),
+		StartedAt:       run.StartedAt,
+		FinishedAt:      run.FinishedAt,
+		DurationSeconds: run.Duration().Seconds(),
+		EventsCount:     run.EventsCount,
+		NewCount:        run.NewCount,
+		ChangedCount:    run.ChangedCount,
+		DuplicateCount:  run.DuplicateCount,
+		FailedCount:     run.FailedCount,
+		IncompleteCount: run.IncompleteCount,
+		SkippedCount:    run.SkippedCount,
+		BlockedCount:    run.BlockedCount,
+		Problems:        problems,
+		Error:           run.Error,
+	}
+}
+
+// MapScrapeRunsToResponse конвертирует слайс запусков скрапера в слайс DTO.
+func MapScrapeRunsToResponse(runs []domain.ScrapeRun) []ScrapeRunResponse {
+	result := make([]ScrapeRunResponse, len(runs))
+	for i, run := range runs {
+		result[i] = MapScrapeRunToResponse(run)
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"eventsBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+// StatusChangeResponse — DTO для ответа с переходом статуса события.
+type StatusChangeResponse struct {
+	ID        uuid.UUID `json:"id"`
+	From      string    `json:"from,omitempty"` // Пустой при создании события
+	To        string    `json:"to"`
+	ActorType string    `json:"actor_type"`
+	ActorID   string    `json:"actor_id,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MapStatusChangeToResponse конвертирует доменную модель перехода статуса в DTO.
+func MapStatusChangeToResponse(c domain.StatusChange) StatusChangeResponse {
+	return StatusChangeResponse{
+		ID:        c.ID,
+		From:      string(c.From),
+		To:        string(c.To),
+		ActorType: string(c.Actor.Type),
+		ActorID:   c.Actor.ID,
+		Reason:    c.Reason,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// MapStatusChangesToResponse конвертирует слайс переходов статуса в слайс DTO.
+func MapStatusChangesToResponse(changes []domain.StatusChange) []StatusChangeResponse {
+	result := make([]StatusChangeResponse, len(changes))
+	for i, c := range changes {
+		result[i] = MapStatusChangeToResponse(c)
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"eventsBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+// TagResponse — DTO для ответа с тегом.
+type TagResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	EventsCount int       `json:"events_count"`
+}
+
+// TagAliasDTO — DTO синонима тега в запросах и ответах.
+type TagAliasDTO struct {
+	Alias string `json:"alias"` // Синоним или перевод
+	Tag   string `json:"tag"`   // Тег, которым заменяется синоним
+}
+
+// MapTagsToResponse конвертирует слайс тегов в слайс DTO.
+func MapTagsToResponse(tags []domain.Tag) []TagResponse {
+	result := make([]TagResponse, len(tags))
+	for i, t := range tags {
+		result[i] = TagResponse{ID: t.ID, Name: t.Name, EventsCount: t.EventsCount}
+	}
+	return result
+}
+
+// MapTagAliasToDTO конвертирует доменную модель синонима тега в DTO.
+func MapTagAliasToDTO(alias domain.TagAlias) TagAliasDTO {
+	return TagAliasDTO{Alias: alias.Alias, Tag: alias.Tag}
+}
+
+// MapTagAliasesToDTO конвертирует слайс синонимов тегов в слайс DTO.
+func MapTagAliasesToDTO(aliases []domain.TagAlias) []TagAliasDTO {
+	result := make([]TagAliasDTO, len(aliases))
+	for i, a := range aliases {
+		result[i] = MapTagAliasToDTO(a)
+	}
+	return result
+}
+
+// CategoryResponse — DTO для ответа с категорией события.
+type CategoryResponse struct {
+	Category string `json:"category"` // Код категории
+	Label    string `json:"label"`    // Название для хэштега
+}
+
+// MapCategoriesToResponse конвертирует список категорий в слайс DTO.
+func MapCategoriesToResponse(categories []domain.Category) []CategoryResponse {
+	result := make([]CategoryResponse, len(categories))
+	for i, c := range categories {
+		result[i] = CategoryResponse{Category: string(c), Label: c.Label()}
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -92,14 +93,43 @@ func (h *EventHandler) ChangeEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.EndDate != nil && req.EndDate.Before(req.Date) {
+		h.respondError(log, fmt.Errorf("end_date is before date"), w, http.StatusBadRequest)
+		return
+	}
+
+	if req.Price.Min < 0 || req.Price.Max < 0 || !req.Price.IsValidAvailability() {
+		h.respondError(log, fmt.Errorf("invalid price"), w, http.StatusBadRequest)
+		return
+	}
+
+	if _, ok := domain.ParseCategory(req.Category); req.Category != "" && !ok {
+		h.respondError(log, fmt.Errorf("invalid category: %s", req.Category), w, http.StatusBadRequest)
+		return
+	}
+
 	event := dto.MapEventRequestToDomain(req, parsedID)
 
+	ctx := r.Context()
+	current, err := h.repository.FindEventByID(ctx, parsedID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to get event: %w", err), w, statusCode(err))
+		return
+	}
+	// Без статуса в запросе событие остаётся в текущем статусе
+	if event.Status == "" {
+		event.Status = current.Status
+	}
+	if err := domain.CheckTransition(current.Status, event.Status, domain.ActorFromContext(ctx)); err != nil {
+		h.respondError(log, err, w, http.StatusConflict)
+		return
+	}
+
 	log.Info("changing event", slog.String("eventID", eventID))
 
-	ctx := r.Context()
 	updated, err := h.repository.UpdateEvent(ctx, event)
 	if err != nil {
-		h.respondError(log, fmt.Errorf("failed to update event: %w", err), w, http.StatusInternalServerError)
+		h.respondError(log, fmt.Errorf("failed to update event: %w", err), w, statusCode(err))
 		return
 	}
 
@@ -120,6 +150,11 @@ func (h *EventHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 		h.respondError(log, fmt.Errorf("empty eventId"), w, http.StatusBadRequest)
 		return
 	}
+	parsedID, err := uuid.Parse(eventID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("invalid eventId: %w", err), w, http.StatusBadRequest)
+		return
+	}
 
 	var req dto.UpdateStatusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -132,6 +167,10 @@ func (h *EventHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 		h.respondError(log, fmt.Errorf("invalid status: %s", req.Status), w, http.StatusBadRequest)
 		return
 	}
+	if req.Status == string(domain.EventStatusDuplicate) {
+		h.respondError(log, fmt.Errorf("events are marked as duplicates only by deduplication"), w, http.StatusBadRequest)
+		return
+	}
 
 	log.Info("updating event status",
 		slog.String("eventID", eventID),
@@ -139,17 +178,33 @@ func (h *EventHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	)
 
 	ctx := r.Context()
-	event, err := h.repository.FindEventByID(ctx, uuid.Must(uuid.Parse(eventID)))
+	event, err := h.repository.FindEventByID(ctx, parsedID)
 	if err != nil {
-		h.respondError(log, fmt.Errorf("failed to get event: %w", err), w, http.StatusInternalServerError)
+		h.respondError(log, fmt.Errorf("failed to get event: %w", err), w, statusCode(err))
 		return
 	}
 	oldStatus := event.Status
 
+	// Дубликат сначала отделяется от канонического события (POST /api/v1/events/{eventId}/split)
+	if oldStatus == domain.EventStatusDuplicate {
+		h.respondError(log, fmt.Errorf("event is a duplicate of %s, split it first", event.CanonicalID), w, http.StatusConflict)
+		return
+	}
+
+	if err := domain.CheckTransition(oldStatus, domain.EventStatus(req.Status), domain.ActorFromContext(ctx)); err != nil {
+		h.respondError(log, err, w, http.StatusConflict)
+		return
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "изменено через API"
+	}
+
 	event.Status = domain.EventStatus(req.Status)
-	err = h.repository.UpdateEventStatus(ctx, event.ID, string(event.Status))
+	err = h.repository.UpdateEventStatus(ctx, event.ID, string(event.Status), reason)
 	if err != nil {
-		h.respondError(log, fmt.Errorf("failed to update event status: %w", err), w, http.StatusInternalServerError)
+		h.respondError(log, fmt.Errorf("failed to update event status: %w", err), w, statusCode(err))
 		return
 	}
 
@@ -157,7 +212,7 @@ func (h *EventHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 		err = h.eventOrchestrator.SendEventToTelegram(&event)
 		if err != nil {
 			event.Status = domain.EventStatus(oldStatus)
-			err = h.repository.UpdateEventStatus(ctx, event.ID, string(event.Status))
+			err = h.repository.UpdateEventStatus(ctx, event.ID, string(event.Status), "не удалось отправить на модерацию")
 			if err != nil {
 				h.respondError(log, fmt.Errorf("failed to update event status: %w", err), w, http.StatusInternalServerError)
 				return
@@ -172,6 +227,147 @@ func (h *EventHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SplitEvent обрабатывает POST /api/v1/events/{eventId}/split
+// Отделяет ошибочно объединённый дубликат от канонического события: событие становится
+// самостоятельным и отправляется в AI, как новое.
+func (h *EventHandler) SplitEvent(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.EventHandler.SplitEvent()"
+	log := h.log.With(slog.String("op", op))
+
+	eventID := chi.URLParam(r, "eventId")
+	parsedID, err := uuid.Parse(eventID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("invalid eventId: %w", err), w, http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	event, err := h.repository.FindEventByID(ctx, parsedID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to get event: %w", err), w, statusCode(err))
+		return
+	}
+	if event.Status != domain.EventStatusDuplicate {
+		h.respondError(log, fmt.Errorf("event %s is not a duplicate", eventID), w, http.StatusConflict)
+		return
+	}
+
+	log.Info("splitting duplicate event",
+		slog.String("eventID", eventID),
+		slog.String("canonicalID", event.CanonicalID.String()),
+	)
+
+	split, err := h.repository.SplitDuplicate(ctx, parsedID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to split event: %w", err), w, statusCode(err))
+		return
+	}
+
+	// Событие уже в статусе NEW: если AI сейчас недоступен, оно будет обогащено при следующем запуске
+	if err := h.eventOrchestrator.SendEventToAI(&split); err != nil {
+		log.Warn("failed to send split event to AI", sl.Err(err))
+	}
+
+	response := dto.MapDomainToEventResponse(split)
+
+	if err := utils.Json(w, http.StatusOK, response); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
+
+// GetEventHistory обрабатывает GET /api/v1/events/{eventId}/history
+// Возвращает историю изменений события от старых ревизий к новым.
+func (h *EventHandler) GetEventHistory(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.EventHandler.GetEventHistory()"
+	log := h.log.With(slog.String("op", op))
+
+	eventID := chi.URLParam(r, "eventId")
+	parsedID, err := uuid.Parse(eventID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("invalid eventId: %w", err), w, http.StatusBadRequest)
+		return
+	}
+
+	revisions, err := h.repository.FindEventRevisions(r.Context(), parsedID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to get event history: %w", err), w, http.StatusInternalServerError)
+		return
+	}
+
+	response := dto.MapRevisionsToResponse(revisions)
+
+	if err := utils.Json(w, http.StatusOK, response); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
+
+// RevertEvent обрабатывает POST /api/v1/events/{eventId}/revert
+// Возвращает поля события к состоянию после ревизии revision_id; статус не меняется.
+func (h *EventHandler) RevertEvent(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.EventHandler.RevertEvent()"
+	log := h.log.With(slog.String("op", op))
+
+	eventID := chi.URLParam(r, "eventId")
+	parsedID, err := uuid.Parse(eventID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("invalid eventId: %w", err), w, http.StatusBadRequest)
+		return
+	}
+
+	var req dto.RevertEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(log, fmt.Errorf("cannot decode json: %w", err), w, http.StatusBadRequest)
+		return
+	}
+	if req.RevisionID == uuid.Nil {
+		h.respondError(log, fmt.Errorf("empty revision_id"), w, http.StatusBadRequest)
+		return
+	}
+
+	log.Info("reverting event",
+		slog.String("eventID", eventID),
+		slog.String("revisionID", req.RevisionID.String()),
+	)
+
+	reverted, err := h.repository.RevertEvent(r.Context(), parsedID, req.RevisionID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to revert event: %w", err), w, statusCode(err))
+		return
+	}
+
+	response := dto.MapDomainToEventResponse(reverted)
+
+	if err := utils.Json(w, http.StatusOK, response); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
+
+// GetStatusHistory обрабатывает GET /api/v1/events/{eventId}/status-history
+// Возвращает переходы статусов события с авторами и причинами от старых к новым.
+func (h *EventHandler) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.EventHandler.GetStatusHistory()"
+	log := h.log.With(slog.String("op", op))
+
+	eventID := chi.URLParam(r, "eventId")
+	parsedID, err := uuid.Parse(eventID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("invalid eventId: %w", err), w, http.StatusBadRequest)
+		return
+	}
+
+	changes, err := h.repository.FindStatusHistory(r.Context(), parsedID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to get status history: %w", err), w, http.StatusInternalServerError)
+		return
+	}
+
+	response := dto.MapStatusChangesToResponse(changes)
+
+	if err := utils.Json(w, http.StatusOK, response); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
+
 func (h *EventHandler) respondError(log *slog.Logger, err error, w http.ResponseWriter, status int) {
 	log.Error("handler error", sl.Err(err))
 	if httpErr := utils.Err(w, status, err); httpErr != nil {
@@ -179,6 +375,24 @@ func (h *EventHandler) respondError(log *slog.Logger, err error, w http.Response
 	}
 }
 
+// statusCode возвращает HTTP-статус ответа на ошибку репозитория:
+// 409 для недопустимого перехода статуса события или конфликта отката,
+// 404 для ненайденного события, ревизии, синонима тега или запуска скрапера,
+// 400 для ревизии другого события, иначе 500.
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTransition), errors.Is(err, domain.ErrRevisionConflict):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrEventNotFound), errors.Is(err, domain.ErrRevisionNotFound),
+		errors.Is(err, domain.ErrTagAliasNotFound), errors.Is(err, domain.ErrScrapeRunNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrRevisionOfOtherEvent):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // isValidStatus проверяет, является ли переданный статус допустимым.
 func isValidStatus(status string) bool {
 	switch domain.EventStatus(status) {
@@ -186,7 +400,9 @@ func isValidStatus(status string) bool {
 		domain.EventStatusAIEnriched,
 		domain.EventStatusReadyToApprove,
 		domain.EventStatusApproved,
-		domain.EventStatusRejected:
+		domain.EventStatusRejected,
+		domain.EventStatusCancelled,
+		domain.EventStatusDuplicate:
 		return true
 	default:
 		return false
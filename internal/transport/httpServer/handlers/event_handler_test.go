@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"eventsBot/internal/models/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// revertRepository — репозиторий, в котором реализован только откат события.
+type revertRepository struct {
+	EventRepository
+	err error
+}
+
+func (r revertRepository) RevertEvent(_ context.Context, eventID uuid.UUID, _ uuid.UUID) (domain.Event, error) {
+	if r.err != nil {
+		return domain.Event{}, fmt.Errorf("repository.RevertEvent(): %w", r.err)
+	}
+	return domain.Event{ID: eventID, Name: "Concert", Status: domain.EventStatusApproved}, nil
+}
+
+func TestRevertEventStatusCodes(t *testing.T) {
+	eventID := uuid.New()
+	revisionID := uuid.New()
+
+	tests := []struct {
+		name    string
+		eventID string
+		body    string
+		err     error
+		want    int
+	}{
+		{"reverted", eventID.String(), fmt.Sprintf(`{"revision_id": %q}`, revisionID), nil, http.StatusOK},
+		{"invalid event id", "not-a-uuid", fmt.Sprintf(`{"revision_id": %q}`, revisionID), nil, http.StatusBadRequest},
+		{"empty revision id", eventID.String(), `{}`, nil, http.StatusBadRequest},
+		{"event not found", eventID.String(), fmt.Sprintf(`{"revision_id": %q}`, revisionID), domain.ErrEventNotFound, http.StatusNotFound},
+		{"revision not found", eventID.String(), fmt.Sprintf(`{"revision_id": %q}`, revisionID), domain.ErrRevisionNotFound, http.StatusNotFound},
+		{"revision of other event", eventID.String(), fmt.Sprintf(`{"revision_id": %q}`, revisionID), domain.ErrRevisionOfOtherEvent, http.StatusBadRequest},
+		{"conflicts with other event", eventID.String(), fmt.Sprintf(`{"revision_id": %q}`, revisionID), domain.ErrRevisionConflict, http.StatusConflict},
+		{"database error", eventID.String(), fmt.Sprintf(`{"revision_id": %q}`, revisionID), fmt.Errorf("connection refused"), http.StatusInternalServerError},
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewEventHandler(log, revertRepository{err: tt.err}, nil)
+
+			router := chi.NewRouter()
+			router.Post("/api/v1/events/{eventId}/revert", h.RevertEvent)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/events/"+tt.eventID+"/revert", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusInvalidEventID(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := NewEventHandler(log, revertRepository{}, nil)
+
+	router := chi.NewRouter()
+	router.Put("/api/v1/events/{eventId}/status", h.UpdateStatus)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/events/not-a-uuid/status", strings.NewReader(`{"status": "APPROVED"}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d (body %s)", rec.Code, http.StatusBadRequest, rec.Body)
+	}
+}
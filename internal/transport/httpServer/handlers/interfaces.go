@@ -3,19 +3,44 @@ package handlers
 import (
 	"context"
 	"eventsBot/internal/models/domain"
+	"eventsBot/internal/scheduler"
 
 	"github.com/google/uuid"
 )
 
 // EventRepository — интерфейс для работы с событиями из хэндлеров.
 type EventRepository interface {
-	UpdateEventStatus(ctx context.Context, eventID uuid.UUID, status string) error
+	UpdateEventStatus(ctx context.Context, eventID uuid.UUID, status string, reason string) error
 	FindEventByID(ctx context.Context, eventID uuid.UUID) (domain.Event, error)
 	ReadAllEvents(ctx context.Context) ([]domain.Event, error)
 	FindEventsByStatus(ctx context.Context, status domain.EventStatus) ([]domain.Event, error)
 	UpdateEvent(ctx context.Context, event domain.Event) (domain.Event, error)
+	SplitDuplicate(ctx context.Context, id uuid.UUID) (domain.Event, error)
+	FindEventRevisions(ctx context.Context, eventID uuid.UUID) ([]domain.EventRevision, error)
+	RevertEvent(ctx context.Context, eventID uuid.UUID, revisionID uuid.UUID) (domain.Event, error)
+	FindStatusHistory(ctx context.Context, eventID uuid.UUID) ([]domain.StatusChange, error)
 }
 
 type EventOrchestrator interface {
 	SendEventToTelegram(event *domain.Event) error
+	SendEventToAI(event *domain.Event) error
+}
+
+// ScrapeRunRepository — интерфейс для получения истории запусков скраперов.
+type ScrapeRunRepository interface {
+	FindScrapeRuns(ctx context.Context, filter domain.ScrapeRunFilter) ([]domain.ScrapeRun, error)
+	FindScrapeRunByRequestID(ctx context.Context, requestID uuid.UUID) (domain.ScrapeRun, error)
+}
+
+// TagRepository — интерфейс для работы с тегами и справочником синонимов.
+type TagRepository interface {
+	FindTags(ctx context.Context) ([]domain.Tag, error)
+	FindTagAliases(ctx context.Context) ([]domain.TagAlias, error)
+	SaveTagAlias(ctx context.Context, alias domain.TagAlias) (domain.TagAlias, error)
+	DeleteTagAlias(ctx context.Context, alias string) error
+}
+
+// ScheduleProvider — интерфейс для получения расписания скрапинга.
+type ScheduleProvider interface {
+	ScheduleEntries() []scheduler.EntryStatus
 }
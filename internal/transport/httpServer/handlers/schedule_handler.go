@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"eventsBot/internal/transport/httpServer/handlers/dto"
+	"eventsBot/internal/utils"
+	"eventsBot/internal/utils/logger/sl"
+)
+
+type ScheduleHandler struct {
+	schedule ScheduleProvider
+	log      *slog.Logger
+}
+
+func NewScheduleHandler(log *slog.Logger, schedule ScheduleProvider) *ScheduleHandler {
+	return &ScheduleHandler{
+		schedule: schedule,
+		log:      log,
+	}
+}
+
+// GetSchedule обрабатывает GET /api/v1/schedule
+// Возвращает расписание скрапинга сайтов с временем последнего и следующего запуска.
+func (h *ScheduleHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.ScheduleHandler.GetSchedule()"
+	log := h.log.With(slog.String("op", op))
+
+	response := dto.MapScheduleEntriesToResponse(h.schedule.ScheduleEntries())
+
+	if err := utils.Json(w, http.StatusOK, response); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
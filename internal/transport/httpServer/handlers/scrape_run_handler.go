@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/transport/httpServer/handlers/dto"
+	"eventsBot/internal/utils"
+	"eventsBot/internal/utils/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultScrapeRunsLimit — число запусков в ответе, если limit не задан.
+	defaultScrapeRunsLimit = 50
+	// maxScrapeRunsLimit — максимальное число запусков в ответе.
+	maxScrapeRunsLimit = 500
+)
+
+type ScrapeRunHandler struct {
+	repository ScrapeRunRepository
+	log        *slog.Logger
+}
+
+func NewScrapeRunHandler(log *slog.Logger, repo ScrapeRunRepository) *ScrapeRunHandler {
+	return &ScrapeRunHandler{
+		repository: repo,
+		log:        log,
+	}
+}
+
+// GetScrapeRuns обрабатывает GET /api/v1/scrape-runs?site=...&status=...&limit=...&offset=...
+// Возвращает историю запусков скраперов, начиная с самого свежего.
+func (h *ScrapeRunHandler) GetScrapeRuns(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.ScrapeRunHandler.GetScrapeRuns()"
+	log := h.log.With(slog.String("op", op))
+
+	query := r.URL.Query()
+	filter := domain.ScrapeRunFilter{
+		SiteName: query.Get("site"),
+		Status:   domain.ScrapeRunStatus(query.Get("status")),
+		Limit:    defaultScrapeRunsLimit,
+	}
+
+	if filter.Status != "" && !isValidScrapeRunStatus(filter.Status) {
+		h.respondError(log, fmt.Errorf("invalid status filter: %s", filter.Status), w, http.StatusBadRequest)
+		return
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value <= 0 || value > maxScrapeRunsLimit {
+			h.respondError(log, fmt.Errorf("invalid limit: %s (expected 1-%d)", limit, maxScrapeRunsLimit), w, http.StatusBadRequest)
+			return
+		}
+		filter.Limit = value
+	}
+
+	if offset := query.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			h.respondError(log, fmt.Errorf("invalid offset: %s", offset), w, http.StatusBadRequest)
+			return
+		}
+		filter.Offset = value
+	}
+
+	runs, err := h.repository.FindScrapeRuns(r.Context(), filter)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to get scrape runs: %w", err), w, http.StatusInternalServerError)
+		return
+	}
+
+	response := dto.MapScrapeRunsToResponse(runs)
+
+	if err := utils.Json(w, http.StatusOK, response); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
+
+// GetScrapeRun обрабатывает GET /api/v1/scrape-runs/{requestId}
+func (h *ScrapeRunHandler) GetScrapeRun(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.ScrapeRunHandler.GetScrapeRun()"
+	log := h.log.With(slog.String("op", op))
+
+	requestID, err := uuid.Parse(chi.URLParam(r, "requestId"))
+	if err != nil {
+		h.respondError(log, fmt.Errorf("invalid requestId: %w", err), w, http.StatusBadRequest)
+		return
+	}
+
+	run, err := h.repository.FindScrapeRunByRequestID(r.Context(), requestID)
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to get scrape run: %w", err), w, statusCode(err))
+		return
+	}
+
+	if err := utils.Json(w, http.StatusOK, dto.MapScrapeRunToResponse(run)); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
+
+func (h *ScrapeRunHandler) respondError(log *slog.Logger, err error, w http.ResponseWriter, status int) {
+	log.Error("handler error", sl.Err(err))
+	if httpErr := utils.Err(w, status, err); httpErr != nil {
+		log.Error("error sending http response", sl.Err(httpErr))
+	}
+}
+
+// isValidScrapeRunStatus проверяет, является ли переданный статус запуска допустимым.
+func isValidScrapeRunStatus(status domain.ScrapeRunStatus) bool {
+	switch status {
+	case domain.ScrapeRunStatusOK, domain.ScrapeRunStatusDegraded, domain.ScrapeRunStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"eventsBot/internal/models/domain"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// scrapeRunRepository — репозиторий, в котором реализован только поиск запуска по идентификатору задачи.
+type scrapeRunRepository struct {
+	ScrapeRunRepository
+	err error
+}
+
+func (r scrapeRunRepository) FindScrapeRunByRequestID(_ context.Context, requestID uuid.UUID) (domain.ScrapeRun, error) {
+	if r.err != nil {
+		return domain.ScrapeRun{}, fmt.Errorf("repository.FindScrapeRunByRequestID(): %w", r.err)
+	}
+	return domain.ScrapeRun{ID: uuid.New(), RequestID: requestID, SiteName: "lococlub", Status: domain.ScrapeRunStatusOK}, nil
+}
+
+func TestGetScrapeRunStatusCodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		err       error
+		want      int
+	}{
+		{"found", uuid.NewString(), nil, http.StatusOK},
+		{"invalid request id", "not-a-uuid", nil, http.StatusBadRequest},
+		{"run not found", uuid.NewString(), domain.ErrScrapeRunNotFound, http.StatusNotFound},
+		{"database error", uuid.NewString(), fmt.Errorf("connection refused"), http.StatusInternalServerError},
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewScrapeRunHandler(log, scrapeRunRepository{err: tt.err})
+
+			router := chi.NewRouter()
+			router.Get("/api/v1/scrape-runs/{requestId}", h.GetScrapeRun)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/scrape-runs/"+tt.requestID, nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
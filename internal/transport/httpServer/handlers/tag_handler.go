@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"eventsBot/internal/models/domain"
+	"eventsBot/internal/transport/httpServer/handlers/dto"
+	"eventsBot/internal/utils"
+	"eventsBot/internal/utils/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type TagHandler struct {
+	repository TagRepository
+	log        *slog.Logger
+}
+
+func NewTagHandler(log *slog.Logger, repo TagRepository) *TagHandler {
+	return &TagHandler{
+		repository: repo,
+		log:        log,
+	}
+}
+
+// GetTags обрабатывает GET /api/v1/tags
+// Возвращает теги и число событий с каждым из них.
+func (h *TagHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.TagHandler.GetTags()"
+	log := h.log.With(slog.String("op", op))
+
+	tags, err := h.repository.FindTags(r.Context())
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to get tags: %w", err), w, http.StatusInternalServerError)
+		return
+	}
+
+	if err := utils.Json(w, http.StatusOK, dto.MapTagsToResponse(tags)); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
+
+// GetCategories обрабатывает GET /api/v1/tags/categories
+// Возвращает контролируемый список категорий событий.
+func (h *TagHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.TagHandler.GetCategories()"
+	log := h.log.With(slog.String("op", op))
+
+	if err := utils.Json(w, http.StatusOK, dto.MapCategoriesToResponse(domain.Categories())); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
+
+// GetAliases обрабатывает GET /api/v1/tags/aliases
+// Возвращает справочник синонимов тегов.
+func (h *TagHandler) GetAliases(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.TagHandler.GetAliases()"
+	log := h.log.With(slog.String("op", op))
+
+	aliases, err := h.repository.FindTagAliases(r.Context())
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to get tag aliases: %w", err), w, http.StatusInternalServerError)
+		return
+	}
+
+	if err := utils.Json(w, http.StatusOK, dto.MapTagAliasesToDTO(aliases)); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
+
+// SaveAlias обрабатывает PUT /api/v1/tags/aliases
+// Добавляет или изменяет синоним; сохранённый тег с названием синонима объединяется с тегом.
+func (h *TagHandler) SaveAlias(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.TagHandler.SaveAlias()"
+	log := h.log.With(slog.String("op", op))
+
+	var req dto.TagAliasDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(log, fmt.Errorf("cannot decode json: %w", err), w, http.StatusBadRequest)
+		return
+	}
+
+	alias, tag := domain.NormalizeTag(req.Alias), domain.NormalizeTag(req.Tag)
+	if alias == "" || tag == "" || alias == tag {
+		h.respondError(log, fmt.Errorf("invalid alias: %q -> %q", req.Alias, req.Tag), w, http.StatusBadRequest)
+		return
+	}
+
+	log.Info("saving tag alias", slog.String("alias", alias), slog.String("tag", tag))
+
+	saved, err := h.repository.SaveTagAlias(r.Context(), domain.TagAlias{Alias: alias, Tag: tag})
+	if err != nil {
+		h.respondError(log, fmt.Errorf("failed to save tag alias: %w", err), w, http.StatusInternalServerError)
+		return
+	}
+
+	if err := utils.Json(w, http.StatusOK, dto.MapTagAliasToDTO(saved)); err != nil {
+		log.Error("error encoding response", sl.Err(err))
+	}
+}
+
+// DeleteAlias обрабатывает DELETE /api/v1/tags/aliases/{alias}
+func (h *TagHandler) DeleteAlias(w http.ResponseWriter, r *http.Request) {
+	op := "httpServer.handlers.TagHandler.DeleteAlias()"
+	log := h.log.With(slog.String("op", op))
+
+	// Синонимы обычно кириллические и приходят в пути закодированными
+	alias, err := url.PathUnescape(chi.URLParam(r, "alias"))
+	if err != nil || alias == "" {
+		h.respondError(log, fmt.Errorf("empty alias"), w, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.repository.DeleteTagAlias(r.Context(), alias); err != nil {
+		h.respondError(log, fmt.Errorf("failed to delete tag alias: %w", err), w, statusCode(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TagHandler) respondError(log *slog.Logger, err error, w http.ResponseWriter, status int) {
+	log.Error("handler error", sl.Err(err))
+	if httpErr := utils.Err(w, status, err); httpErr != nil {
+		log.Error("error sending http response", sl.Err(httpErr))
+	}
+}
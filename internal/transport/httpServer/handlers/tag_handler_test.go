@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"eventsBot/internal/models/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// aliasRepository — репозиторий, в котором реализовано только удаление синонима тега.
+type aliasRepository struct {
+	TagRepository
+	err error
+}
+
+func (r aliasRepository) DeleteTagAlias(_ context.Context, alias string) error {
+	if r.err != nil {
+		return fmt.Errorf("repository.DeleteTagAlias(): %w: %s", r.err, alias)
+	}
+	return nil
+}
+
+func TestDeleteAliasStatusCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"deleted", nil, http.StatusNoContent},
+		{"alias not found", domain.ErrTagAliasNotFound, http.StatusNotFound},
+		{"database error", fmt.Errorf("connection refused"), http.StatusInternalServerError},
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTagHandler(log, aliasRepository{err: tt.err})
+
+			router := chi.NewRouter()
+			router.Delete("/api/v1/tags/aliases/{alias}", h.DeleteAlias)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/tags/aliases/%D0%BA%D0%BE%D0%BD%D1%86%D0%B5%D1%80%D1%82%D1%8B", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"eventsBot/internal/models/domain"
+)
+
+// ActorMiddleware записывает изменения событий, сделанные через API, в историю от имени клиента.
+// По умолчанию клиент определяется по адресу; Authorization заменяет его пользователем из токена.
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ctx := domain.WithActor(r.Context(), domain.Actor{Type: domain.ActorAPI, ID: host})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"eventsBot/internal/models/domain"
 	"eventsBot/internal/utils/jwt"
 	"context"
 	"log/slog"
@@ -8,6 +9,19 @@ import (
 	"strings"
 )
 
+// RoleAdmin — роль пользователя, которому доступны изменения справочников.
+const RoleAdmin = "admin"
+
+type contextKey string
+
+const userKey contextKey = "user"
+
+// UserFromContext возвращает пользователя из токена, проверенного Authorization.
+func UserFromContext(ctx context.Context) (*UserClaims, bool) {
+	user, ok := ctx.Value(userKey).(*UserClaims)
+	return user, ok
+}
+
 func Authorization(secret string) func(next http.Handler) http.Handler {
 	op := "middleware.Authorization()"
 	log := slog.With(
@@ -29,13 +43,12 @@ func Authorization(secret string) func(next http.Handler) http.Handler {
 			user, err := jwt.ParseAndValidateToken[UserClaims](tokenString, secret)
 			if err != nil {
 				log.Error("error parse jwt token", slog.String("error", err.Error()))
-				w.WriteHeader(http.StatusInternalServerError)
+				w.WriteHeader(http.StatusUnauthorized)
 				return
 			}
 
-			type contextKey string
-			const userKey contextKey = "user"
 			ctx := context.WithValue(r.Context(), userKey, user)
+			ctx = domain.WithActor(ctx, domain.Actor{Type: domain.ActorAPI, ID: user.Data.Email})
 			next.ServeHTTP(w, r.WithContext(ctx))
 		}
 
@@ -43,6 +56,24 @@ func Authorization(secret string) func(next http.Handler) http.Handler {
 	}
 }
 
+// RequireRole пропускает только пользователей с ролью role; ставится после Authorization.
+func RequireRole(role string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := UserFromContext(r.Context())
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if user.Data.Role != role {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func LoggerMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		slog.Info("",
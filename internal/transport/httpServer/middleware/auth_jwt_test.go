@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"eventsBot/internal/models/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func signToken(t *testing.T, secret, role string, expiresAt time.Time) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp":  expiresAt.Unix(),
+		"data": map[string]any{"email": "admin@example.com", "role": role},
+	})
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestAuthorizationRequireRole(t *testing.T) {
+	const secret = "test-secret"
+	hour := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"not bearer", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"wrong secret", "Bearer " + signToken(t, "other-secret", RoleAdmin, hour), http.StatusUnauthorized},
+		{"expired", "Bearer " + signToken(t, secret, RoleAdmin, time.Now().Add(-time.Hour)), http.StatusUnauthorized},
+		{"not admin", "Bearer " + signToken(t, secret, "moderator", hour), http.StatusForbidden},
+		{"admin", "Bearer " + signToken(t, secret, RoleAdmin, hour), http.StatusNoContent},
+	}
+
+	var actor domain.Actor
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = domain.ActorFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := Authorization(secret)(RequireRole(RoleAdmin)(next))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/tags/aliases", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+
+	if want := (domain.Actor{Type: domain.ActorAPI, ID: "admin@example.com"}); actor != want {
+		t.Errorf("actor = %+v, want %+v", actor, want)
+	}
+}
+
+func TestRequireRoleWithoutAuthorization(t *testing.T) {
+	handler := RequireRole(RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler called without authorization")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/tags/aliases/jazz", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
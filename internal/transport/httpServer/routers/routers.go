@@ -10,12 +10,20 @@ import (
 )
 
 type Router struct {
-	eventHandler *handlers.EventHandler
+	eventHandler     *handlers.EventHandler
+	scheduleHandler  *handlers.ScheduleHandler
+	scrapeRunHandler *handlers.ScrapeRunHandler
+	tagHandler       *handlers.TagHandler
+	secret           string // Секрет для проверки JWT администратора
 }
 
-func NewRouter(eventHandler *handlers.EventHandler) *Router {
+func NewRouter(eventHandler *handlers.EventHandler, scheduleHandler *handlers.ScheduleHandler, scrapeRunHandler *handlers.ScrapeRunHandler, tagHandler *handlers.TagHandler, secret string) *Router {
 	return &Router{
-		eventHandler: eventHandler,
+		eventHandler:     eventHandler,
+		scheduleHandler:  scheduleHandler,
+		scrapeRunHandler: scrapeRunHandler,
+		tagHandler:       tagHandler,
+		secret:           secret,
 	}
 }
 
@@ -23,6 +31,7 @@ func (r *Router) Mount(mux *chi.Mux) {
 
 	mux.Use(cors.AllowAll().Handler)
 	mux.Use(myMiddleware.LoggerMiddleware)
+	mux.Use(myMiddleware.ActorMiddleware)
 	mux.Use(middleware.Heartbeat("/ping"))
 
 	mux.Route("/api", func(mux chi.Router) {
@@ -31,6 +40,27 @@ func (r *Router) Mount(mux *chi.Mux) {
 				mux.Get("/", r.eventHandler.GetEvents)
 				mux.Put("/{eventId}", r.eventHandler.ChangeEvent)
 				mux.Put("/{eventId}/status", r.eventHandler.UpdateStatus)
+				mux.Post("/{eventId}/split", r.eventHandler.SplitEvent)
+				mux.Get("/{eventId}/history", r.eventHandler.GetEventHistory)
+				mux.Post("/{eventId}/revert", r.eventHandler.RevertEvent)
+				mux.Get("/{eventId}/status-history", r.eventHandler.GetStatusHistory)
+			})
+			mux.Get("/schedule", r.scheduleHandler.GetSchedule)
+			mux.Route("/scrape-runs", func(mux chi.Router) {
+				mux.Get("/", r.scrapeRunHandler.GetScrapeRuns)
+				mux.Get("/{requestId}", r.scrapeRunHandler.GetScrapeRun)
+			})
+			mux.Route("/tags", func(mux chi.Router) {
+				mux.Get("/", r.tagHandler.GetTags)
+				mux.Get("/categories", r.tagHandler.GetCategories)
+				mux.Get("/aliases", r.tagHandler.GetAliases)
+				// Справочник синонимов меняет только администратор
+				mux.Group(func(mux chi.Router) {
+					mux.Use(myMiddleware.Authorization(r.secret))
+					mux.Use(myMiddleware.RequireRole(myMiddleware.RoleAdmin))
+					mux.Put("/aliases", r.tagHandler.SaveAlias)
+					mux.Delete("/aliases/{alias}", r.tagHandler.DeleteAlias)
+				})
 			})
 		})
 	})